@@ -0,0 +1,117 @@
+"""Shareable artifact bundles for single evaluation runs.
+
+Packages a run directory (summary with ABR timeline and stall intervals,
+per-frame metrics, viewport heatmap, optional video and frames) together with
+the movement trace, network trace, and ABR profile it was configured with into
+one `.tar.gz`, so a reproduction case can be shared as a single file.
+"""
+
+from __future__ import annotations
+
+import hashlib
+import io
+import json
+import tarfile
+from pathlib import Path
+
+from tigas.input_control.headless_replayer import HeadlessTraceReplayer
+from tigas.intelligence.abr_client import resolve_abr_profile
+from tigas.shared.content_paths import resolve_content_input
+
+BUNDLE_MANIFEST = "bundle_manifest.json"
+
+_INPUT_LOOKUPS = (
+    ("trace_path", "movement_traces", ".json"),
+    ("network_trace_path", "network_traces", ".csv"),
+    ("scenario_path", "scenarios", ".json"),
+)
+
+
+def _sha256(path: Path) -> str:
+    digest = hashlib.sha256()
+    with path.open("rb") as handle:
+        for block in iter(lambda: handle.read(1 << 20), b""):
+            digest.update(block)
+    return digest.hexdigest()
+
+
+def _resolve_inputs(config: dict) -> list[tuple[Path, str]]:
+    """Return (path, content folder) for each input file the run was configured with."""
+    inputs: list[tuple[Path, str]] = []
+    for key, folder, suffix in _INPUT_LOOKUPS:
+        value = config.get(key)
+        if not value:
+            continue
+        if key == "trace_path":
+            # Scheduled movement traces list several names, the same way the runner reads them.
+            try:
+                names = [name for name, _ in HeadlessTraceReplayer.parse_trace_schedule(str(value))]
+            except ValueError:
+                continue
+        else:
+            names = [str(value)]
+        for name in names:
+            try:
+                resolved = resolve_content_input(name, folder, suffix)
+            except FileNotFoundError:
+                continue
+            if resolved is not None and resolved.suffix.lower() == suffix and (resolved, folder) not in inputs:
+                inputs.append((resolved, folder))
+    if config.get("abr_profile_path"):
+        try:
+            profile_path = resolve_abr_profile(config["abr_profile_path"])
+        except FileNotFoundError:
+            profile_path = None
+        if profile_path is not None:
+            inputs.append((profile_path, "abr_profiles"))
+    return inputs
+
+
+def bundle_run(
+    run_dir: Path,
+    output_path: Path | None = None,
+    include_frames: bool = False,
+    include_video: bool = True,
+) -> Path:
+    """Write one run directory and its configured inputs to a `.tar.gz` archive."""
+    run_dir = Path(run_dir)
+    summary_path = run_dir / "summary.json"
+    if not summary_path.is_file():
+        raise FileNotFoundError(f"Run directory has no summary.json: {run_dir}")
+    with summary_path.open("r", encoding="utf-8") as handle:
+        summary = json.load(handle)
+
+    archive_path = Path(output_path) if output_path is not None else run_dir.parent / f"{run_dir.name}.tar.gz"
+    archive_path.parent.mkdir(parents=True, exist_ok=True)
+    root = run_dir.name
+
+    members: list[tuple[Path, str]] = []
+    for path in sorted(run_dir.rglob("*")):
+        if not path.is_file():
+            continue
+        relative = path.relative_to(run_dir)
+        if not include_frames and relative.parts[0] == "frames":
+            continue
+        if not include_video and path.suffix.lower() == ".mp4":
+            continue
+        members.append((path, f"{root}/{relative.as_posix()}"))
+    for input_path, folder in _resolve_inputs(summary.get("config", {})):
+        members.append((input_path, f"{root}/inputs/{folder}/{input_path.name}"))
+
+    manifest = {
+        "run_dir": str(run_dir),
+        "asset_path": summary.get("config", {}).get("asset_path"),
+        "files": [
+            {"name": name, "size_bytes": path.stat().st_size, "sha256": _sha256(path)}
+            for path, name in members
+        ],
+    }
+    manifest_bytes = json.dumps(manifest, indent=2).encode("utf-8")
+
+    with tarfile.open(archive_path, "w:gz") as archive:
+        for path, name in members:
+            archive.add(path, arcname=name, recursive=False)
+        info = tarfile.TarInfo(f"{root}/{BUNDLE_MANIFEST}")
+        info.size = len(manifest_bytes)
+        archive.addfile(info, io.BytesIO(manifest_bytes))
+    return archive_path
@@ -0,0 +1,156 @@
+"""Synthetic test content for end-to-end runs without real scenes.
+
+Generates a small standard-layout 3DGS PLY, a matching orbit movement trace, a
+stepped network trace, and a bitrate ladder profile, plus a manifest that ties
+them together. CI and new contributors can point the headless runner and the
+evaluator at the output directory instead of downloading captured assets.
+"""
+
+from __future__ import annotations
+
+import json
+import math
+import random
+import struct
+from dataclasses import asdict, dataclass
+from pathlib import Path
+
+from tigas.input_control.headless_replayer import HeadlessTraceReplayer
+
+_STANDARD_PROPERTIES = (
+    "x",
+    "y",
+    "z",
+    "nx",
+    "ny",
+    "nz",
+    "f_dc_0",
+    "f_dc_1",
+    "f_dc_2",
+    "opacity",
+    "scale_0",
+    "scale_1",
+    "scale_2",
+    "rot_0",
+    "rot_1",
+    "rot_2",
+    "rot_3",
+)
+_SH_C0 = 0.28209479177387814
+
+
+@dataclass(slots=True)
+class SyntheticContentConfig:
+    """Parameters of one synthetic content set."""
+
+    num_points: int = 20000
+    shape: str = "sphere"
+    radius: float = 1.0
+    num_frames: int = 120
+    fps: int = 30
+    bitrates_kbps: tuple[int, ...] = (800, 2500, 6000)
+    network_steps_kbps: tuple[int, ...] = (6000, 2500, 800, 2500)
+    network_step_samples: int = 30
+    seed: int = 0
+
+
+def _point_on_shape(rng: random.Random, shape: str, radius: float) -> tuple[float, float, float]:
+    if shape == "sphere":
+        z = rng.uniform(-1.0, 1.0)
+        theta = rng.uniform(0.0, 2.0 * math.pi)
+        ring = math.sqrt(max(0.0, 1.0 - z * z))
+        return (radius * ring * math.cos(theta), radius * ring * math.sin(theta), radius * z)
+    if shape == "cube":
+        return tuple(rng.uniform(-radius, radius) for _ in range(3))
+    raise ValueError(f"Unsupported synthetic shape '{shape}'.")
+
+
+def write_synthetic_ply(output_path: Path, num_points: int, shape: str, radius: float, seed: int) -> Path:
+    """Write a binary standard-layout 3DGS PLY with position-derived colors."""
+    if num_points <= 0:
+        raise ValueError("Synthetic scene requires at least one point.")
+    rng = random.Random(seed)
+    header = "\n".join(
+        [
+            "ply",
+            "format binary_little_endian 1.0",
+            f"element vertex {num_points}",
+            *[f"property float {name}" for name in _STANDARD_PROPERTIES],
+            "end_header",
+            "",
+        ]
+    ).encode("ascii")
+    log_scale = math.log(max(1e-4, radius * 2.0 / math.sqrt(num_points)))
+    vertex = struct.Struct(f"<{len(_STANDARD_PROPERTIES)}f")
+
+    with output_path.open("wb") as handle:
+        handle.write(header)
+        for _ in range(num_points):
+            x, y, z = _point_on_shape(rng, shape, radius)
+            # Position in [-radius, radius] maps to color in [0, 1]; DC terms store (color - 0.5) / C0.
+            colors = [(value / radius * 0.5) / _SH_C0 for value in (x, y, z)]
+            handle.write(vertex.pack(x, y, z, 0.0, 0.0, 0.0, *colors, 4.0, *([log_scale] * 3), 1.0, 0.0, 0.0, 0.0))
+    return output_path
+
+
+def generate_synthetic_content(output_dir: Path, config: SyntheticContentConfig | None = None) -> dict:
+    """Write a complete synthetic content set and return its manifest."""
+    config = config or SyntheticContentConfig()
+    output_dir = Path(output_dir)
+    output_dir.mkdir(parents=True, exist_ok=True)
+
+    scene_path = write_synthetic_ply(
+        output_dir / "scene.ply",
+        num_points=config.num_points,
+        shape=config.shape,
+        radius=config.radius,
+        seed=config.seed,
+    )
+
+    replayer = HeadlessTraceReplayer()
+    samples = replayer.generate_orbit_samples(
+        center=(0.0, 0.0, 0.0),
+        radius=max(0.4, config.radius * 2.2),
+        num_frames=config.num_frames,
+        fps=config.fps,
+    )
+    trace_path = output_dir / "orbit.json"
+    with trace_path.open("w", encoding="utf-8") as handle:
+        json.dump({"samples": [asdict(sample) for sample in samples]}, handle)
+
+    network_path = output_dir / "steps.csv"
+    network_rows = [
+        str(step) for step in config.network_steps_kbps for _ in range(max(1, config.network_step_samples))
+    ]
+    network_path.write_text("\n".join(network_rows) + "\n", encoding="utf-8")
+
+    bitrates = sorted(config.bitrates_kbps)
+    lods = ["full"]
+    if len(bitrates) > 1:
+        lods = ["quant_8bit"] + ["sampled_50"] * (len(bitrates) - 2) + ["full"]
+    profile_path = output_dir / "abr_profile.json"
+    with profile_path.open("w", encoding="utf-8") as handle:
+        json.dump(
+            {
+                "name": "synthetic",
+                "algorithm": "throughput",
+                "bitrates_kbps": bitrates,
+                "lods": lods,
+                "min_bitrate_kbps": bitrates[0],
+                "max_bitrate_kbps": bitrates[-1],
+            },
+            handle,
+            indent=2,
+        )
+
+    manifest = {
+        "generator": "tigas-gen",
+        "config": asdict(config),
+        "scene_ply": str(scene_path),
+        "movement_trace": str(trace_path),
+        "network_trace": str(network_path),
+        "abr_profile": str(profile_path),
+    }
+    with (output_dir / "manifest.json").open("w", encoding="utf-8") as handle:
+        json.dump(manifest, handle, indent=2)
+    return manifest
@@ -0,0 +1,60 @@
+"""Free-space guard for evaluation artifact directories.
+
+Frame dumps are the largest and least essential evaluation artifact. The guard
+samples free space under the output directory every few writes and, once it
+drops below a threshold, pauses those dumps with a prominent warning, so a long
+sweep degrades gracefully instead of filling the disk and crashing the node.
+"""
+
+from __future__ import annotations
+
+import shutil
+import sys
+from dataclasses import dataclass
+from pathlib import Path
+
+
+@dataclass(slots=True)
+class DiskGuardStatus:
+    """Outcome of one guarded artifact stream."""
+
+    min_free_mb: float
+    tripped: bool = False
+    tripped_at_write: int | None = None
+    free_mb_at_trip: float | None = None
+    skipped_writes: int = 0
+
+
+class DiskSpaceGuard:
+    """Allow non-essential writes only while free space stays above a floor."""
+
+    def __init__(self, path: Path, min_free_mb: float = 1024.0, check_every: int = 30) -> None:
+        self.path = Path(path)
+        self.check_every = max(1, int(check_every))
+        self.status = DiskGuardStatus(min_free_mb=max(0.0, float(min_free_mb)))
+        self._writes = 0
+
+    def free_mb(self) -> float:
+        return shutil.disk_usage(self.path).free / (1024.0 * 1024.0)
+
+    def allow_write(self) -> bool:
+        """Return whether the next non-essential artifact should be written."""
+        if self.status.tripped:
+            self.status.skipped_writes += 1
+            return False
+        if self.status.min_free_mb > 0.0 and self._writes % self.check_every == 0:
+            free_mb = self.free_mb()
+            if free_mb < self.status.min_free_mb:
+                self.status.tripped = True
+                self.status.tripped_at_write = self._writes
+                self.status.free_mb_at_trip = free_mb
+                self.status.skipped_writes += 1
+                print(
+                    f"WARNING: free space under {self.path} is {free_mb:.0f} MB "
+                    f"(< {self.status.min_free_mb:.0f} MB); pausing frame dumps.",
+                    file=sys.stderr,
+                    flush=True,
+                )
+                return False
+        self._writes += 1
+        return True
@@ -16,8 +16,11 @@ from pathlib import Path
 
 import numpy as np
 
+from tigas.evaluation.disk_guard import DiskSpaceGuard
+from tigas.evaluation.heatmap import ViewportHeatmap, save_heatmap
 from tigas.evaluation.metrics import ssim_proxy
 from tigas.orchestration.ablation_runner import HeadlessAblationRunner
+from tigas.orchestration.shutdown_report import ShutdownReport
 from tigas.shared.types import ExperimentConfig
 
 
@@ -30,8 +33,10 @@ class EvaluationRunResult:
 class EvaluationRunner:
     """Runs repeatable offline evaluations without polluting runtime paths."""
 
-    def __init__(self) -> None:
+    def __init__(self, min_free_mb: float = 1024.0) -> None:
         self.runtime_runner = HeadlessAblationRunner()
+        self.min_free_mb = min_free_mb
+        self.shutdown = ShutdownReport()
 
     @staticmethod
     def _build_run_dir(output_root: Path, config: ExperimentConfig) -> Path:
@@ -124,6 +129,8 @@ class EvaluationRunner:
         frame_rows: list[dict] = []
         ssim_values: list[float] = []
         captured_frames: list[np.ndarray] = []
+        heatmap = ViewportHeatmap()
+        disk_guard = DiskSpaceGuard(run_dir, min_free_mb=self.min_free_mb)
 
         def on_frame(
             frame_bytes: bytes,
@@ -134,7 +141,9 @@ class EvaluationRunner:
             render_ms: float,
         ) -> None:
             frame_rgb = np.frombuffer(frame_bytes, dtype=np.uint8).reshape((height, width, 3)).copy()
-            self._write_ppm(frames_dir / f"frame_{frame_id:05d}.ppm", frame_rgb)
+            if disk_guard.allow_write():
+                self._write_ppm(frames_dir / f"frame_{frame_id:05d}.ppm", frame_rgb)
+            heatmap.add_pose(datagram.timestamp_ms, datagram.camera_matrix_4x4)
 
             active_pixels = np.count_nonzero(frame_rgb.sum(axis=2))
             coverage = float(active_pixels / (width * height))
@@ -162,7 +171,8 @@ class EvaluationRunner:
             if capture_frames:
                 captured_frames.append(frame_rgb)
 
-        runtime_summary = self.runtime_runner.run_one(config, frame_callback=on_frame)
+        runtime_summary = self.runtime_runner.run_one(config, frame_callback=on_frame, artifact_dir=run_dir)
+        self.shutdown.record_run(runtime_summary)
 
         metrics_csv = run_dir / "frame_metrics.csv"
         with metrics_csv.open("w", encoding="utf-8", newline="") as handle:
@@ -180,11 +190,45 @@ class EvaluationRunner:
             writer.writeheader()
             writer.writerows(frame_rows)
 
-        video_path, encoder_used = self._encode_video(
-            frames_dir=frames_dir,
-            output_path=run_dir / "headless_render.mp4",
-            fps=config.fps,
-        )
+        heatmap_path = save_heatmap(heatmap, run_dir / "viewport_heatmap.json")
+
+        timeline_csv = run_dir / "quality_timeline.csv"
+        with timeline_csv.open("w", encoding="utf-8", newline="") as handle:
+            writer = csv.DictWriter(
+                handle,
+                fieldnames=["index", "start_ms", "frames", "lod", "bitrate_kbps_mean", "stall_ms"],
+                extrasaction="ignore",
+            )
+            writer.writeheader()
+            writer.writerows(runtime_summary.get("quality_timeline", []))
+
+        bandwidth_csv = run_dir / "bandwidth_usage.csv"
+        bandwidth_usage = runtime_summary.get("bandwidth_usage", {})
+        transports = sorted(bandwidth_usage.get("by_transport", {}))
+        with bandwidth_csv.open("w", encoding="utf-8", newline="") as handle:
+            writer = csv.writer(handle)
+            writer.writerow(["elapsed_ms", "total_bytes", *[f"{name}_bytes" for name in transports]])
+            for snapshot in bandwidth_usage.get("snapshots", []):
+                writer.writerow(
+                    [
+                        snapshot["elapsed_ms"],
+                        snapshot["total_bytes"],
+                        *[snapshot["by_transport"].get(name, 0) for name in transports],
+                    ]
+                )
+
+        video_path: Path | None = None
+        encoder_used: str | None = None
+        video_skipped_reason: str | None = None
+        if disk_guard.status.tripped and disk_guard.status.tripped_at_write == 0:
+            # No frame was dumped, so there is nothing for ffmpeg to encode.
+            video_skipped_reason = "disk_guard_skipped_all_frames"
+        else:
+            video_path, encoder_used = self._encode_video(
+                frames_dir=frames_dir,
+                output_path=run_dir / "headless_render.mp4",
+                fps=config.fps,
+            )
 
         coverage_values = [float(row["coverage"]) for row in frame_rows]
         brightness_values = [float(row["brightness"]) for row in frame_rows]
@@ -194,11 +238,16 @@ class EvaluationRunner:
             "output_dir": str(run_dir),
             "frames_dir": str(frames_dir),
             "frame_metrics_csv": str(metrics_csv),
+            "viewport_heatmap_json": str(heatmap_path),
+            "quality_timeline_csv": str(timeline_csv),
+            "bandwidth_usage_csv": str(bandwidth_csv),
             "coverage_mean": float(np.mean(coverage_values)) if coverage_values else 0.0,
             "brightness_mean": float(np.mean(brightness_values)) if brightness_values else 0.0,
             "ssim_vs_full_mean": float(np.mean(ssim_values)) if ssim_values else None,
-            "video_path": str(video_path),
+            "video_path": str(video_path) if video_path is not None else None,
             "video_encoder": encoder_used,
+            "video_skipped_reason": video_skipped_reason,
+            "disk_guard": asdict(disk_guard.status),
         }
 
         summary_path = run_dir / "summary.json"
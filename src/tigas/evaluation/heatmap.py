@@ -0,0 +1,119 @@
+"""Viewport heatmap aggregation from pose streams.
+
+Each camera pose is reduced to its viewing direction and binned into an
+equirectangular tile grid (yaw columns x pitch rows) per fixed-duration
+segment. Heatmaps from several runs can be merged so later experiments can use
+tile hit frequencies for content-aware encoding decisions.
+"""
+
+from __future__ import annotations
+
+import json
+import math
+from dataclasses import dataclass, field
+from pathlib import Path
+
+
+def view_direction(camera_matrix_4x4: list[float]) -> tuple[float, float, float]:
+    """Return the world-space viewing direction of a row-major camera-to-world matrix."""
+    # The third rotation column stores the camera backward axis.
+    dx = -float(camera_matrix_4x4[2])
+    dy = -float(camera_matrix_4x4[6])
+    dz = -float(camera_matrix_4x4[10])
+    length = math.sqrt(dx * dx + dy * dy + dz * dz)
+    if length < 1e-8:
+        return (0.0, 0.0, -1.0)
+    return (dx / length, dy / length, dz / length)
+
+
+@dataclass(slots=True)
+class ViewportHeatmap:
+    """Per-segment tile hit counts over an equirectangular grid."""
+
+    tile_cols: int = 8
+    tile_rows: int = 4
+    segment_duration_ms: float = 1000.0
+    segments: dict[int, list[int]] = field(default_factory=dict)
+    sample_count: int = 0
+
+    def tile_for(self, camera_matrix_4x4: list[float]) -> int:
+        """Return the flat tile index hit by one camera pose."""
+        dx, dy, dz = view_direction(camera_matrix_4x4)
+        yaw = math.atan2(dx, -dz)
+        pitch = math.asin(max(-1.0, min(1.0, dy)))
+        col = int((yaw + math.pi) / (2.0 * math.pi) * self.tile_cols)
+        row = int((math.pi / 2.0 - pitch) / math.pi * self.tile_rows)
+        col = min(self.tile_cols - 1, max(0, col))
+        row = min(self.tile_rows - 1, max(0, row))
+        return row * self.tile_cols + col
+
+    def add_pose(self, timestamp_ms: float, camera_matrix_4x4: list[float]) -> None:
+        """Accumulate one pose sample into its segment heatmap."""
+        segment_index = int(max(0.0, timestamp_ms) // max(1.0, self.segment_duration_ms))
+        counts = self.segments.setdefault(segment_index, [0] * (self.tile_cols * self.tile_rows))
+        counts[self.tile_for(camera_matrix_4x4)] += 1
+        self.sample_count += 1
+
+    def merge(self, other: "ViewportHeatmap") -> None:
+        """Add another heatmap with the same grid into this one."""
+        if (
+            other.tile_cols != self.tile_cols
+            or other.tile_rows != self.tile_rows
+            or other.segment_duration_ms != self.segment_duration_ms
+        ):
+            raise ValueError("Cannot merge heatmaps with different grids or segment durations.")
+        for segment_index, counts in other.segments.items():
+            target = self.segments.setdefault(segment_index, [0] * len(counts))
+            for tile, count in enumerate(counts):
+                target[tile] += count
+        self.sample_count += other.sample_count
+
+    def frequencies(self, segment_index: int) -> list[float]:
+        """Return normalized tile hit frequencies for one segment."""
+        counts = self.segments.get(segment_index)
+        if not counts:
+            return [0.0] * (self.tile_cols * self.tile_rows)
+        total = float(sum(counts))
+        return [count / total for count in counts]
+
+    def to_dict(self) -> dict:
+        return {
+            "tile_cols": self.tile_cols,
+            "tile_rows": self.tile_rows,
+            "segment_duration_ms": self.segment_duration_ms,
+            "sample_count": self.sample_count,
+            "segments": {str(index): counts for index, counts in sorted(self.segments.items())},
+        }
+
+    @classmethod
+    def from_dict(cls, payload: dict) -> "ViewportHeatmap":
+        return cls(
+            tile_cols=int(payload["tile_cols"]),
+            tile_rows=int(payload["tile_rows"]),
+            segment_duration_ms=float(payload["segment_duration_ms"]),
+            segments={int(index): [int(v) for v in counts] for index, counts in payload["segments"].items()},
+            sample_count=int(payload.get("sample_count", 0)),
+        )
+
+
+def save_heatmap(heatmap: ViewportHeatmap, output_path: Path) -> Path:
+    """Write one heatmap artifact as JSON."""
+    with output_path.open("w", encoding="utf-8") as handle:
+        json.dump(heatmap.to_dict(), handle, indent=2)
+    return output_path
+
+
+def load_heatmap(heatmap_path: Path) -> ViewportHeatmap:
+    """Load one heatmap artifact."""
+    with heatmap_path.open("r", encoding="utf-8") as handle:
+        return ViewportHeatmap.from_dict(json.load(handle))
+
+
+def aggregate_heatmaps(heatmap_paths: list[Path]) -> ViewportHeatmap:
+    """Merge heatmap artifacts from several runs into one aggregate."""
+    if not heatmap_paths:
+        raise ValueError("At least one heatmap artifact is required.")
+    aggregate = load_heatmap(heatmap_paths[0])
+    for path in heatmap_paths[1:]:
+        aggregate.merge(load_heatmap(path))
+    return aggregate
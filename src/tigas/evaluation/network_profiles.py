@@ -0,0 +1,68 @@
+"""Synthetic network throughput profiles for ABR regression checks.
+
+Each profile is a deterministic per-chunk bandwidth series with a known shape
+(steady, step down, step up, oscillation, outage), so ABR behavior can be
+asserted against expectations instead of eyeballed on captured traces. The
+series can be fed to `AbrSimulator` directly or written as a network trace CSV
+for headless runs.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+from typing import Callable
+
+
+def constant(kbps: int = 5000, samples: int = 30) -> list[int]:
+    return [int(kbps)] * samples
+
+
+def step_down(high_kbps: int = 8000, low_kbps: int = 1000, samples: int = 40, at: int = 15) -> list[int]:
+    return [int(high_kbps)] * at + [int(low_kbps)] * (samples - at)
+
+
+def step_up(low_kbps: int = 1000, high_kbps: int = 8000, samples: int = 40, at: int = 10) -> list[int]:
+    return [int(low_kbps)] * at + [int(high_kbps)] * (samples - at)
+
+
+def oscillating(high_kbps: int = 6000, low_kbps: int = 2000, samples: int = 40, period: int = 4) -> list[int]:
+    half = max(1, period // 2)
+    return [int(high_kbps) if (index // half) % 2 == 0 else int(low_kbps) for index in range(samples)]
+
+
+def outage(
+    kbps: int = 5000,
+    outage_kbps: int = 50,
+    samples: int = 40,
+    at: int = 15,
+    length: int = 4,
+) -> list[int]:
+    return [int(outage_kbps) if at <= index < at + length else int(kbps) for index in range(samples)]
+
+
+SYNTHETIC_NETWORK_PROFILES: dict[str, Callable[..., list[int]]] = {
+    "constant": constant,
+    "step_down": step_down,
+    "step_up": step_up,
+    "oscillating": oscillating,
+    "outage": outage,
+}
+
+
+def synthetic_network(name: str, **params) -> list[int]:
+    """Return the bandwidth series for a named synthetic profile."""
+    try:
+        generator = SYNTHETIC_NETWORK_PROFILES[name]
+    except KeyError:
+        raise ValueError(
+            f"Unknown synthetic network profile '{name}'. Expected one of {sorted(SYNTHETIC_NETWORK_PROFILES)}."
+        ) from None
+    return generator(**params)
+
+
+def write_network_trace(output_path: Path, bandwidth_kbps: list[int]) -> Path:
+    """Write a bandwidth series in the one-value-per-line network trace format."""
+    output_path = Path(output_path)
+    output_path.parent.mkdir(parents=True, exist_ok=True)
+    output_path.write_text("\n".join(str(value) for value in bandwidth_kbps) + "\n", encoding="utf-8")
+    return output_path
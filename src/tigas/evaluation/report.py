@@ -0,0 +1,153 @@
+"""Comparative reporting across evaluation run directories.
+
+Collects per-run `summary.json` artifacts from several output roots and
+aggregates quality, ABR switching, stall proxies, and bandwidth usage so
+experiment batches can be compared without manual notebook work.
+"""
+
+from __future__ import annotations
+
+import json
+import statistics
+from pathlib import Path
+
+
+def find_run_summaries(run_dir: Path) -> list[Path]:
+    """Return all per-run summary files below an evaluation output root."""
+    if run_dir.is_file():
+        return [run_dir]
+    if not run_dir.exists():
+        raise FileNotFoundError(f"Run artifact directory not found: {run_dir}")
+    return sorted(run_dir.rglob("summary.json"))
+
+
+def load_run_summaries(run_dir: Path) -> list[dict]:
+    """Load every per-run summary below one output root."""
+    summaries: list[dict] = []
+    for summary_path in find_run_summaries(run_dir):
+        with summary_path.open("r", encoding="utf-8") as handle:
+            summaries.append(json.load(handle))
+    return summaries
+
+
+def _mean_of(summaries: list[dict], key: str) -> float | None:
+    values = [float(item[key]) for item in summaries if isinstance(item.get(key), (int, float))]
+    if not values:
+        return None
+    return float(statistics.fmean(values))
+
+
+def _sum_of(summaries: list[dict], key: str) -> int | None:
+    values = [int(item[key]) for item in summaries if isinstance(item.get(key), (int, float))]
+    if not values:
+        return None
+    return int(sum(values))
+
+
+def _merge_bytes(summaries: list[dict], breakdown: str) -> dict[str, int]:
+    merged: dict[str, int] = {}
+    for item in summaries:
+        for key, size in item.get("bandwidth_usage", {}).get(breakdown, {}).items():
+            merged[key] = merged.get(key, 0) + int(size)
+    return dict(sorted(merged.items()))
+
+
+def _budget_hit_rate(summaries: list[dict]) -> float | None:
+    annotated = sum(int(item.get("latency_budget", {}).get("annotated", 0)) for item in summaries)
+    met = sum(int(item.get("latency_budget", {}).get("met", 0)) for item in summaries)
+    return float(met / annotated) if annotated else None
+
+
+def _feature_flag_values(summaries: list[dict]) -> dict[str, list]:
+    """Distinct effective values per flag; more than one value means the group mixes cohorts."""
+    values: dict[str, list] = {}
+    for item in summaries:
+        for name, value in item.get("feature_flags", {}).items():
+            if value not in values.setdefault(name, []):
+                values[name].append(value)
+    return dict(sorted(values.items()))
+
+
+def summarize_runs(label: str, summaries: list[dict]) -> dict:
+    """Aggregate one group of run summaries into comparable statistics."""
+    render_means = [
+        float(item["render_time_ms"]["mean"])
+        for item in summaries
+        if isinstance(item.get("render_time_ms"), dict) and "mean" in item["render_time_ms"]
+    ]
+    frames_total = _sum_of(summaries, "frames_rendered")
+    empty_frames_total = _sum_of(summaries, "abr_buffer_empty_frames")
+    stall_ratio = None
+    if frames_total and empty_frames_total is not None:
+        stall_ratio = float(empty_frames_total / frames_total)
+
+    return {
+        "label": label,
+        "num_runs": len(summaries),
+        "abr_profiles": sorted({str(item["abr_profile"]) for item in summaries if item.get("abr_profile")}),
+        "ssim_vs_full_mean": _mean_of(summaries, "ssim_vs_full_mean"),
+        "abr_switch_count_mean": _mean_of(summaries, "abr_switch_count"),
+        "abr_switch_count_total": _sum_of(summaries, "abr_switch_count"),
+        "abr_buffer_empty_frames_total": empty_frames_total,
+        "stall_frame_ratio": stall_ratio,
+        "stall_count_total": _sum_of(summaries, "stall_count"),
+        "stall_time_ms_mean": _mean_of(summaries, "stall_time_ms"),
+        "abr_target_bitrate_kbps_mean": _mean_of(summaries, "abr_target_bitrate_kbps_mean"),
+        "abr_throughput_kbps_mean": _mean_of(summaries, "abr_throughput_kbps_mean"),
+        "render_ms_mean": float(statistics.fmean(render_means)) if render_means else None,
+        "effective_fps_mean": _mean_of(summaries, "effective_fps"),
+        "frames_rendered_total": frames_total,
+        "latency_budget_hit_rate": _budget_hit_rate(summaries),
+        "feature_flags": _feature_flag_values(summaries),
+        "bytes_total": sum(int(item.get("bandwidth_usage", {}).get("total_bytes", 0)) for item in summaries),
+        "bytes_by_transport": _merge_bytes(summaries, "by_transport"),
+        "bytes_by_representation": _merge_bytes(summaries, "by_representation"),
+        "bytes_by_content": _merge_bytes(summaries, "by_content"),
+    }
+
+
+def build_comparison_report(run_dirs: list[str]) -> dict:
+    """Build a comparative report for two or more run artifact directories."""
+    if len(run_dirs) < 2:
+        raise ValueError("A comparison report requires at least two run directories.")
+
+    groups: list[dict] = []
+    for run_dir in run_dirs:
+        path = Path(run_dir)
+        summaries = load_run_summaries(path)
+        if not summaries:
+            raise ValueError(f"No summary.json artifacts found under {path}.")
+        groups.append(summarize_runs(label=str(path), summaries=summaries))
+
+    return {"status": "ok", "num_groups": len(groups), "groups": groups}
+
+
+def _format_cell(value: object, digits: int) -> str:
+    if value is None:
+        return "n/a"
+    if isinstance(value, float):
+        return f"{value:.{digits}f}"
+    return str(value)
+
+
+def render_markdown(report: dict) -> str:
+    """Render a comparison report as a markdown table."""
+    lines = [
+        "| Runs | ABR | SSIM vs full | Switches (mean) | Stall frame ratio | Stalls | Target kbps | Throughput kbps | Render mean ms | FPS | Budget hit rate | MB served | Source |",
+        "|---:|---|---:|---:|---:|---:|---:|---:|---:|---:|---:|---:|---|",
+    ]
+    for group in report["groups"]:
+        lines.append(
+            f"| {group['num_runs']} | {', '.join(group['abr_profiles']) or 'none'} "
+            f"| {_format_cell(group['ssim_vs_full_mean'], 4)} "
+            f"| {_format_cell(group['abr_switch_count_mean'], 2)} "
+            f"| {_format_cell(group['stall_frame_ratio'], 4)} "
+            f"| {_format_cell(group['stall_count_total'], 0)} "
+            f"| {_format_cell(group['abr_target_bitrate_kbps_mean'], 1)} "
+            f"| {_format_cell(group['abr_throughput_kbps_mean'], 1)} "
+            f"| {_format_cell(group['render_ms_mean'], 3)} "
+            f"| {_format_cell(group['effective_fps_mean'], 2)} "
+            f"| {_format_cell(group['latency_budget_hit_rate'], 4)} "
+            f"| {_format_cell(group['bytes_total'] / 1e6, 2)} | {group['label']} |"
+        )
+    return "\n".join(lines) + "\n"
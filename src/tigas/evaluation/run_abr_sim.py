@@ -0,0 +1,77 @@
+"""CLI entrypoint for offline ABR simulation over network traces."""
+
+from __future__ import annotations
+
+import argparse
+import json
+from pathlib import Path
+
+from tigas.evaluation.abr_sim import AbrSimConfig, AbrSimulator, summarize_timeline, write_timeline_csv
+from tigas.evaluation.network_profiles import synthetic_network
+from tigas.input_control.headless_replayer import HeadlessTraceReplayer
+from tigas.intelligence.abr_client import load_abr_profile, resolve_abr_profile, restrict_ladder
+from tigas.shared.content_paths import resolve_content_input
+
+
+def build_parser() -> argparse.ArgumentParser:
+    parser = argparse.ArgumentParser(description="Simulate TIGAS ABR profiles offline over a network trace")
+    parser.add_argument(
+        "--network-trace",
+        required=True,
+        help=(
+            "Network trace CSV path, name in network_traces (e.g. lte_steps), "
+            "or synthetic:<profile> (constant, step_down, step_up, oscillating, outage)"
+        ),
+    )
+    parser.add_argument(
+        "--abr-profiles",
+        default="throughput,bola,robustmpc",
+        help="Comma-separated ABR profile paths or names in abr_profiles",
+    )
+    parser.add_argument("--chunk-duration-ms", type=float, default=1000.0, help="Media duration per decision")
+    parser.add_argument("--initial-buffer-ms", type=float, default=2000.0, help="Buffer level at start")
+    parser.add_argument("--max-buffer-ms", type=float, default=6000.0, help="Buffer cap")
+    parser.add_argument("--max-chunks", type=int, default=0, help="Limit simulated chunks (0 = whole trace)")
+    parser.add_argument("--min-profile", default="", help="Lowest ladder rung to simulate, as pN")
+    parser.add_argument("--max-profile", default="", help="Highest ladder rung to simulate, as pN")
+    parser.add_argument("--output-dir", default="", help="Optional directory for per-profile timeline CSVs")
+    return parser
+
+
+def main() -> None:
+    args = build_parser().parse_args()
+    if args.network_trace.startswith("synthetic:"):
+        trace_path = args.network_trace
+        bandwidth_kbps = synthetic_network(args.network_trace.split(":", 1)[1])
+    else:
+        trace_path = resolve_content_input(args.network_trace, "network_traces", ".csv")
+        bandwidth_kbps = HeadlessTraceReplayer().load_network_trace(str(trace_path))
+    sim_config = AbrSimConfig(
+        chunk_duration_ms=args.chunk_duration_ms,
+        initial_buffer_ms=args.initial_buffer_ms,
+        max_buffer_ms=args.max_buffer_ms,
+        max_chunks=args.max_chunks,
+    )
+
+    output_dir = Path(args.output_dir) if args.output_dir else None
+    if output_dir is not None:
+        output_dir.mkdir(parents=True, exist_ok=True)
+
+    results: list[dict] = []
+    for profile_arg in [item.strip() for item in args.abr_profiles.split(",") if item.strip()]:
+        profile = restrict_ladder(
+            load_abr_profile(resolve_abr_profile(profile_arg)),
+            min_profile=args.min_profile,
+            max_profile=args.max_profile,
+        )
+        steps = AbrSimulator(profile, sim_config).run(bandwidth_kbps)
+        summary = summarize_timeline(profile, steps)
+        if output_dir is not None:
+            summary["timeline_csv"] = str(write_timeline_csv(steps, output_dir / f"{profile.name}_timeline.csv"))
+        results.append(summary)
+
+    print(json.dumps({"status": "ok", "network_trace": str(trace_path), "results": results}, indent=2))
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,33 @@
+"""CLI entrypoint for packaging one evaluation run into a shareable archive."""
+
+from __future__ import annotations
+
+import argparse
+import json
+from pathlib import Path
+
+from tigas.evaluation.bundle import bundle_run
+
+
+def build_parser() -> argparse.ArgumentParser:
+    parser = argparse.ArgumentParser(description="Bundle a TIGAS run directory and its inputs into a .tar.gz")
+    parser.add_argument("run_dir", help="Run directory containing summary.json")
+    parser.add_argument("--output", default="", help="Archive path (default: <run_dir>.tar.gz)")
+    parser.add_argument("--include-frames", action="store_true", help="Include dumped PPM frames")
+    parser.add_argument("--no-video", action="store_true", help="Exclude the encoded MP4")
+    return parser
+
+
+def main() -> None:
+    args = build_parser().parse_args()
+    archive_path = bundle_run(
+        Path(args.run_dir),
+        output_path=Path(args.output) if args.output else None,
+        include_frames=bool(args.include_frames),
+        include_video=not args.no_video,
+    )
+    print(json.dumps({"status": "ok", "bundle_path": str(archive_path)}, indent=2))
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,64 @@
+"""CLI entrypoint for generating synthetic test content."""
+
+from __future__ import annotations
+
+import argparse
+import json
+from pathlib import Path
+
+from tigas.evaluation.content_gen import SyntheticContentConfig, generate_synthetic_content
+
+
+def _parse_int_list(raw: str) -> tuple[int, ...]:
+    values = tuple(int(item.strip()) for item in raw.split(",") if item.strip())
+    if not values:
+        raise argparse.ArgumentTypeError("expected a comma-separated list of integers")
+    return values
+
+
+def build_parser() -> argparse.ArgumentParser:
+    parser = argparse.ArgumentParser(description="Generate a synthetic TIGAS scene, traces, and ABR ladder")
+    parser.add_argument("--output-dir", default="outputs/synthetic", help="Directory for generated content")
+    parser.add_argument("--points", type=int, default=20000, help="Number of gaussians in scene.ply")
+    parser.add_argument("--shape", default="sphere", choices=["sphere", "cube"], help="Point distribution")
+    parser.add_argument("--radius", type=float, default=1.0, help="Scene radius")
+    parser.add_argument("--num-frames", type=int, default=120, help="Samples in the orbit movement trace")
+    parser.add_argument("--fps", type=int, default=30, help="Orbit trace frame rate")
+    parser.add_argument(
+        "--bitrates-kbps",
+        type=_parse_int_list,
+        default=(800, 2500, 6000),
+        help="Comma-separated ABR ladder rungs",
+    )
+    parser.add_argument(
+        "--network-steps-kbps",
+        type=_parse_int_list,
+        default=(6000, 2500, 800, 2500),
+        help="Comma-separated network trace step levels",
+    )
+    parser.add_argument("--network-step-samples", type=int, default=30, help="Samples per network step")
+    parser.add_argument("--seed", type=int, default=0, help="Seed for point placement")
+    return parser
+
+
+def main() -> None:
+    args = build_parser().parse_args()
+    manifest = generate_synthetic_content(
+        Path(args.output_dir),
+        SyntheticContentConfig(
+            num_points=args.points,
+            shape=args.shape,
+            radius=args.radius,
+            num_frames=args.num_frames,
+            fps=args.fps,
+            bitrates_kbps=args.bitrates_kbps,
+            network_steps_kbps=args.network_steps_kbps,
+            network_step_samples=args.network_step_samples,
+            seed=args.seed,
+        ),
+    )
+    print(json.dumps({"status": "ok", **manifest}, indent=2))
+
+
+if __name__ == "__main__":
+    main()
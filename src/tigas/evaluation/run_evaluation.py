@@ -6,6 +6,8 @@ import argparse
 import json
 
 from tigas.evaluation.evaluator import EvaluationRunner
+from tigas.shared.build_info import BuildInfoAction
+from tigas.shared.feature_flags import parse_flag_overrides
 from tigas.shared.types import ExperimentConfig
 
 
@@ -39,13 +41,40 @@ def _parse_resolutions(raw: str) -> list[tuple[int, int]]:
 
 def build_parser() -> argparse.ArgumentParser:
     parser = argparse.ArgumentParser(description="Run offline TIGAS evaluation sweeps")
+    parser.add_argument(
+        "--version",
+        action=BuildInfoAction,
+        help="Print build info, git commit, features, and content inventory as JSON and exit",
+    )
     parser.add_argument("--ply-path", required=True, help="Path to .ply point cloud")
     parser.add_argument(
         "--movement-trace",
         default="",
-        help="Movement trace path or trace name in movement_traces (e.g. Circular)",
+        help=(
+            "Movement trace path or trace name in movement_traces (e.g. Circular). "
+            "Use name[@start_ms],... to switch traces mid-run (e.g. Circular,Linear@5000)"
+        ),
     )
     parser.add_argument("--trace-json", default="", help="Deprecated alias for --movement-trace")
+    parser.add_argument(
+        "--trace-rate-hz",
+        type=float,
+        default=0.0,
+        help="Resample movement traces to this rate with slerp interpolation (0 keeps native timing)",
+    )
+    parser.add_argument(
+        "--max-pose-rate-hz",
+        type=float,
+        default=0.0,
+        help="Drop pose datagrams arriving faster than this rate (0 disables limiting)",
+    )
+    parser.add_argument(
+        "--pose-horizon-ms",
+        type=float,
+        default=0.0,
+        help="Advertise and enforce the useful pose rate for this prediction horizon "
+        "when --max-pose-rate-hz is unset (0 disables)",
+    )
     parser.add_argument(
         "--network-trace",
         default="",
@@ -56,6 +85,21 @@ def build_parser() -> argparse.ArgumentParser:
         default="",
         help="ABR profile JSON path or profile name in abr_profiles",
     )
+    parser.add_argument(
+        "--min-profile",
+        default="",
+        help="Lowest ladder rung the ABR may select, as pN (e.g. p1 excludes p0)",
+    )
+    parser.add_argument(
+        "--max-profile",
+        default="",
+        help="Highest ladder rung the ABR may select, as pN (e.g. p2)",
+    )
+    parser.add_argument(
+        "--scenario",
+        default="",
+        help="Scenario JSON path or name in scenarios of timed actions to apply during the run",
+    )
     parser.add_argument("--output-dir", default="outputs/evaluation", help="Evaluation output root")
     parser.add_argument("--num-frames", type=int, default=120, help="Frames per run")
     parser.add_argument("--fps", type=int, default=30, help="Frame rate for rendering and video")
@@ -81,11 +125,52 @@ def build_parser() -> argparse.ArgumentParser:
         default="8,6,4,3",
         help="Comma-separated quantization bits for quantized runs",
     )
+    parser.add_argument(
+        "--min-free-mb",
+        type=float,
+        default=1024.0,
+        help="Pause frame dumps when free space under the output directory drops below this (0 disables)",
+    )
+    parser.add_argument(
+        "--seed",
+        type=int,
+        default=None,
+        help="Per-run seed for Python and NumPy randomness, recorded in each summary (random when omitted)",
+    )
+    parser.add_argument(
+        "--feature",
+        action="append",
+        default=[],
+        metavar="NAME=VALUE",
+        help="Override a feature flag from tigas.shared.feature_flags (repeatable)",
+    )
+    parser.add_argument(
+        "--latency-budget-ms",
+        type=float,
+        default=0.0,
+        help="Annotate every datagram with this response deadline and report budget hit rates (0 disables)",
+    )
+    parser.add_argument(
+        "--black-box-seconds",
+        type=float,
+        default=0.0,
+        help="Seconds of recent per-frame events to keep and dump on stalls or errors (0 disables)",
+    )
+    parser.add_argument(
+        "--deterministic",
+        action="store_true",
+        help="Keep wall-clock render time out of ABR inputs so identical inputs give identical decisions",
+    )
     return parser
 
 
 def main() -> None:
-    args = build_parser().parse_args()
+    parser = build_parser()
+    args = parser.parse_args()
+    try:
+        feature_flags = parse_flag_overrides(args.feature)
+    except ValueError as exc:
+        parser.error(str(exc))
     movement_trace = args.movement_trace if args.movement_trace else args.trace_json
     sparsity_levels = _parse_sparsity_levels(args.sparsity_levels)
     resolutions = _parse_resolutions(args.resolutions)
@@ -98,8 +183,14 @@ def main() -> None:
         network_profile="wifi",
         default_lod="full",
         asset_path=args.ply_path,
+        trace_rate_hz=args.trace_rate_hz,
+        max_pose_rate_hz=args.max_pose_rate_hz,
+        pose_horizon_ms=args.pose_horizon_ms,
         network_trace_path=args.network_trace,
         abr_profile_path=args.abr_profile,
+        min_profile=args.min_profile,
+        max_profile=args.max_profile,
+        scenario_path=args.scenario or None,
         output_dir=args.output_dir,
         num_frames=args.num_frames,
         fps=args.fps,
@@ -108,16 +199,28 @@ def main() -> None:
         max_points=args.max_points,
         renderer_backend=args.renderer_backend,
         quant_bits=max(quant_bits_list),
+        seed=args.seed,
+        deterministic=bool(args.deterministic),
+        black_box_seconds=args.black_box_seconds,
+        latency_budget_ms=args.latency_budget_ms,
+        feature_flags=feature_flags,
     )
 
-    report = EvaluationRunner().run_tradeoff_curve(
-        base_config=base_config,
-        output_root=args.output_dir,
-        sparsity_levels=sparsity_levels,
-        resolutions=resolutions,
-        quant_bits_list=quant_bits_list,
-    )
-    print(json.dumps(report, indent=2))
+    runner = EvaluationRunner(min_free_mb=args.min_free_mb)
+    try:
+        report = runner.run_tradeoff_curve(
+            base_config=base_config,
+            output_root=args.output_dir,
+            sparsity_levels=sparsity_levels,
+            resolutions=resolutions,
+            quant_bits_list=quant_bits_list,
+        )
+        print(json.dumps(report, indent=2))
+    except BaseException as exc:
+        runner.shutdown.record_error(exc)
+        raise
+    finally:
+        runner.shutdown.write(args.output_dir)
 
 
 if __name__ == "__main__":
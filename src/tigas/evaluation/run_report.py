@@ -0,0 +1,47 @@
+"""CLI entrypoint for comparing evaluation run artifact directories."""
+
+from __future__ import annotations
+
+import argparse
+import json
+from pathlib import Path
+
+from tigas.evaluation.report import build_comparison_report, render_markdown
+
+
+def build_parser() -> argparse.ArgumentParser:
+    parser = argparse.ArgumentParser(description="Compare two or more TIGAS run artifact directories")
+    parser.add_argument(
+        "run_dirs",
+        nargs="+",
+        help="Evaluation output roots (or single run directories) containing summary.json files",
+    )
+    parser.add_argument("--output-json", default="", help="Optional path for the JSON report")
+    parser.add_argument("--output-md", default="", help="Optional path for the markdown report")
+    return parser
+
+
+def main() -> None:
+    parser = build_parser()
+    args = parser.parse_args()
+    if len(args.run_dirs) < 2:
+        parser.error("at least two run directories are required")
+
+    report = build_comparison_report(args.run_dirs)
+
+    if args.output_json:
+        output_json = Path(args.output_json)
+        output_json.parent.mkdir(parents=True, exist_ok=True)
+        with output_json.open("w", encoding="utf-8") as handle:
+            json.dump(report, handle, indent=2)
+
+    if args.output_md:
+        output_md = Path(args.output_md)
+        output_md.parent.mkdir(parents=True, exist_ok=True)
+        output_md.write_text(render_markdown(report), encoding="utf-8")
+
+    print(json.dumps(report, indent=2))
+
+
+if __name__ == "__main__":
+    main()
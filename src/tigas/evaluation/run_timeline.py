@@ -0,0 +1,36 @@
+"""CLI entrypoint for exporting a run's session timeline as HTML."""
+
+from __future__ import annotations
+
+import argparse
+import json
+from pathlib import Path
+
+from tigas.evaluation.timeline_html import TIMELINE_AXES, export_session_timeline
+
+
+def build_parser() -> argparse.ArgumentParser:
+    parser = argparse.ArgumentParser(description="Render a self-contained HTML timeline for one TIGAS run")
+    parser.add_argument("run_dir", help="Run directory containing summary.json and frame_metrics.csv")
+    parser.add_argument("--output", default="", help="HTML path (default: <run_dir>/session_timeline.html)")
+    parser.add_argument(
+        "--axis",
+        choices=TIMELINE_AXES,
+        default="session",
+        help="Time axis: session (wall clock) or media (playback position, stalls collapse)",
+    )
+    return parser
+
+
+def main() -> None:
+    args = build_parser().parse_args()
+    html_path = export_session_timeline(
+        Path(args.run_dir),
+        output_path=Path(args.output) if args.output else None,
+        axis=args.axis,
+    )
+    print(json.dumps({"status": "ok", "timeline_path": str(html_path)}, indent=2))
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,238 @@
+"""Self-contained HTML session timelines from run artifacts.
+
+Reads one run directory (`summary.json` plus `frame_metrics.csv`) and draws
+bitrate, pose rate, render time, ABR switches, trace switches, scenario
+actions, degraded-mode periods, and stalls on a single time axis as inline SVG. The output has no
+external scripts or styles, so it can be opened straight from a bundle or
+attached to an issue.
+
+The axis is session time by default. With `axis="media"` every event is moved
+to media-timeline coordinates through the run's `media_clock`. Stalls then
+collapse to the instant playback froze, and events line up with what was on
+screen.
+"""
+
+from __future__ import annotations
+
+import csv
+import html
+import json
+from pathlib import Path
+
+from tigas.intelligence.media_clock import MediaClock
+
+TIMELINE_HTML_NAME = "session_timeline.html"
+TIMELINE_AXES = ("session", "media")
+
+_WIDTH = 1000.0
+_LANE_HEIGHT = 90.0
+_LANE_GAP = 24.0
+_MARGIN_LEFT = 120.0
+_MARGIN_TOP = 30.0
+
+
+def _load_frame_rows(run_dir: Path) -> list[dict]:
+    metrics_csv = run_dir / "frame_metrics.csv"
+    if not metrics_csv.exists():
+        return []
+    with metrics_csv.open("r", encoding="utf-8", newline="") as handle:
+        return [
+            {"timestamp_ms": float(row["timestamp_ms"]), "render_time_ms": float(row["render_time_ms"])}
+            for row in csv.DictReader(handle)
+        ]
+
+
+def _pose_rate_series(timestamps_ms: list[float], bucket_ms: float = 1000.0) -> list[tuple[float, float]]:
+    """Return (bucket start ms, poses per second) for each bucket with traffic."""
+    counts: dict[int, int] = {}
+    for timestamp_ms in timestamps_ms:
+        bucket = int(timestamp_ms // bucket_ms)
+        counts[bucket] = counts.get(bucket, 0) + 1
+    return [(bucket * bucket_ms, count * 1000.0 / bucket_ms) for bucket, count in sorted(counts.items())]
+
+
+def _on_axis(to_axis, events: list[dict], keys: tuple[str, ...] = ("timestamp_ms",)) -> list[dict]:
+    return [{**event, **{key: to_axis(float(event[key])) for key in keys}} for event in events]
+
+
+def build_session_timeline(run_dir: Path, axis: str = "session") -> dict:
+    """Collect the time-aligned series and events drawn by the HTML export."""
+    if axis not in TIMELINE_AXES:
+        raise ValueError(f"Unknown timeline axis '{axis}'. Expected one of {list(TIMELINE_AXES)}.")
+    run_dir = Path(run_dir)
+    summary_path = run_dir / "summary.json"
+    if not summary_path.exists():
+        raise FileNotFoundError(f"Run summary not found: {summary_path}")
+    with summary_path.open("r", encoding="utf-8") as handle:
+        summary = json.load(handle)
+
+    def to_axis(timestamp_ms: float) -> float:
+        return timestamp_ms
+
+    # Quality timeline rows are bucketed by playback position (media time).
+    position_to_axis = to_axis
+    if axis == "media":
+        if "media_clock" not in summary:
+            raise ValueError(f"{summary_path} has no media_clock; rerun to export a media-time axis.")
+        to_axis = MediaClock.from_dict(summary["media_clock"]).to_media_ms
+    elif "media_clock" in summary:
+        position_to_axis = MediaClock.from_dict(summary["media_clock"]).to_session_ms
+
+    frame_rows = _on_axis(to_axis, _load_frame_rows(run_dir))
+    timestamps_ms = [row["timestamp_ms"] for row in frame_rows]
+    bitrate = [
+        (position_to_axis(float(row["start_ms"])), float(row["bitrate_kbps_mean"]))
+        for row in summary.get("quality_timeline", [])
+        if row.get("bitrate_kbps_mean") is not None
+    ]
+    stalls = _on_axis(to_axis, summary.get("stall_intervals", []), keys=("start_ms", "end_ms"))
+    events_end_ms = [float(stall["end_ms"]) for stall in stalls]
+    end_ms = max([0.0, *timestamps_ms, *(start for start, _ in bitrate), *events_end_ms])
+
+    return {
+        "title": Path(summary.get("output_dir") or run_dir).name,
+        "axis": axis,
+        "abr_profile": summary.get("abr_profile"),
+        "trace_source": summary.get("trace_source"),
+        "end_ms": end_ms,
+        "bitrate_kbps": bitrate,
+        "pose_rate_hz": _pose_rate_series(timestamps_ms),
+        "render_time_ms": [(row["timestamp_ms"], row["render_time_ms"]) for row in frame_rows],
+        "abr_switches": _on_axis(to_axis, summary.get("abr_switches", [])),
+        "trace_switches": _on_axis(to_axis, summary.get("trace_switches", [])),
+        "scenario_events": _on_axis(to_axis, summary.get("scenario_events", [])),
+        "stalls": stalls,
+        "degraded": _on_axis(to_axis, summary.get("abr_degraded_intervals", []), keys=("start_ms", "end_ms")),
+    }
+
+
+def _x(timestamp_ms: float, end_ms: float) -> float:
+    return _MARGIN_LEFT + (_WIDTH - _MARGIN_LEFT) * (timestamp_ms / end_ms if end_ms > 0 else 0.0)
+
+
+def _lane(index: int, label: str, points: list[tuple[float, float]], end_ms: float, step: bool) -> list[str]:
+    top = _MARGIN_TOP + index * (_LANE_HEIGHT + _LANE_GAP)
+    bottom = top + _LANE_HEIGHT
+    peak = max((value for _, value in points), default=0.0)
+    parts = [
+        f'<text x="4" y="{top + 14:.1f}" class="label">{html.escape(label)}</text>',
+        f'<text x="4" y="{top + 30:.1f}" class="scale">max {peak:.1f}</text>',
+        f'<line x1="{_MARGIN_LEFT}" y1="{bottom:.1f}" x2="{_WIDTH}" y2="{bottom:.1f}" class="axis"/>',
+    ]
+    if not points:
+        return parts
+
+    def y(value: float) -> float:
+        return bottom - (_LANE_HEIGHT - 6.0) * (value / peak if peak > 0 else 0.0)
+
+    coords: list[str] = []
+    for position, (timestamp_ms, value) in enumerate(points):
+        if step and position > 0:
+            coords.append(f"{_x(timestamp_ms, end_ms):.1f},{y(points[position - 1][1]):.1f}")
+        coords.append(f"{_x(timestamp_ms, end_ms):.1f},{y(value):.1f}")
+    if step:
+        coords.append(f"{_x(end_ms, end_ms):.1f},{y(points[-1][1]):.1f}")
+    parts.append(f'<polyline points="{" ".join(coords)}" class="series"/>')
+    return parts
+
+
+def render_timeline_html(timeline: dict) -> str:
+    """Render a collected timeline as a standalone HTML document."""
+    end_ms = float(timeline["end_ms"])
+    lanes = [
+        ("bitrate kbps", timeline["bitrate_kbps"], True),
+        ("pose rate Hz", timeline["pose_rate_hz"], True),
+        ("render ms", timeline["render_time_ms"], False),
+    ]
+    height = _MARGIN_TOP + len(lanes) * (_LANE_HEIGHT + _LANE_GAP) + 20.0
+    chart_bottom = height - 20.0 - _LANE_GAP
+
+    parts: list[str] = []
+    for interval in timeline.get("degraded", []):
+        x_start = _x(float(interval["start_ms"]), end_ms)
+        width = max(1.0, _x(float(interval["end_ms"]), end_ms) - x_start)
+        tooltip = f"degraded mode {float(interval.get('duration_ms', 0.0)):.0f} ms"
+        parts.append(
+            f'<rect x="{x_start:.1f}" y="{_MARGIN_TOP}" width="{width:.1f}" '
+            f'height="{chart_bottom - _MARGIN_TOP:.1f}" class="degraded">'
+            f"<title>{html.escape(tooltip)}</title></rect>"
+        )
+    for stall in timeline["stalls"]:
+        x_start = _x(float(stall["start_ms"]), end_ms)
+        width = max(1.0, _x(float(stall["end_ms"]), end_ms) - x_start)
+        tooltip = f"stall {stall.get('cause', '')} {float(stall.get('duration_ms', 0.0)):.0f} ms"
+        parts.append(
+            f'<rect x="{x_start:.1f}" y="{_MARGIN_TOP}" width="{width:.1f}" '
+            f'height="{chart_bottom - _MARGIN_TOP:.1f}" class="stall"><title>{html.escape(tooltip)}</title></rect>'
+        )
+    for index, (label, points, step) in enumerate(lanes):
+        parts.extend(_lane(index, label, points, end_ms, step))
+    for switch in timeline["abr_switches"]:
+        x = _x(float(switch["timestamp_ms"]), end_ms)
+        tooltip = (
+            f"{float(switch['timestamp_ms']):.0f} ms: {switch.get('from_kbps')} -> {switch.get('to_kbps')} kbps "
+            f"({switch.get('lod')}, {switch.get('server_reason')})"
+        )
+        parts.append(
+            f'<line x1="{x:.1f}" y1="{_MARGIN_TOP}" x2="{x:.1f}" y2="{chart_bottom:.1f}" class="switch">'
+            f"<title>{html.escape(tooltip)}</title></line>"
+        )
+    for switch in timeline["trace_switches"]:
+        x = _x(float(switch["timestamp_ms"]), end_ms)
+        tooltip = f"trace {switch.get('from_trace')} -> {switch.get('to_trace')}"
+        parts.append(
+            f'<line x1="{x:.1f}" y1="{_MARGIN_TOP}" x2="{x:.1f}" y2="{chart_bottom:.1f}" class="seek">'
+            f"<title>{html.escape(tooltip)}</title></line>"
+        )
+    for event in timeline.get("scenario_events", []):
+        x = _x(float(event["timestamp_ms"]), end_ms)
+        details = ", ".join(
+            f"{key}={value}"
+            for key, value in event.items()
+            if key not in {"action", "timestamp_ms", "media_time_ms"}
+        )
+        tooltip = f"scenario {event['action']} ({details})"
+        parts.append(
+            f'<line x1="{x:.1f}" y1="{_MARGIN_TOP}" x2="{x:.1f}" y2="{chart_bottom:.1f}" class="scenario">'
+            f"<title>{html.escape(tooltip)}</title></line>"
+        )
+    parts.append(
+        f'<text x="{_MARGIN_LEFT}" y="{height - 4:.1f}" class="scale">0 ms</text>'
+        f'<text x="{_WIDTH}" y="{height - 4:.1f}" class="scale" text-anchor="end">'
+        f"{end_ms:.0f} ms {timeline.get('axis', 'session')} time</text>"
+    )
+
+    title = html.escape(str(timeline["title"]))
+    caption = html.escape(
+        f"ABR profile: {timeline.get('abr_profile') or 'none'} | trace: {timeline.get('trace_source') or 'n/a'} | "
+        f"{len(timeline['abr_switches'])} ABR switches, {len(timeline['stalls'])} stalls"
+    )
+    return (
+        "<!DOCTYPE html>\n"
+        '<html lang="en">\n<head>\n<meta charset="utf-8">\n'
+        f"<title>{title} session timeline</title>\n"
+        "<style>\n"
+        "body{font-family:sans-serif;margin:16px}"
+        ".label{font-size:12px;font-weight:bold}.scale{font-size:10px;fill:#555}"
+        ".axis{stroke:#999;stroke-width:1}.series{fill:none;stroke:#1f77b4;stroke-width:1.5}"
+        ".switch{stroke:#ff7f0e;stroke-width:1}.seek{stroke:#2ca02c;stroke-width:1.5;stroke-dasharray:4 3}"
+        ".scenario{stroke:#9467bd;stroke-width:1.5;stroke-dasharray:1 2}"
+        ".stall{fill:#d62728;fill-opacity:0.2}.degraded{fill:#7f7f7f;fill-opacity:0.2}\n"
+        "</style>\n</head>\n<body>\n"
+        f"<h1>{title}</h1>\n<p>{caption}</p>\n"
+        f'<svg xmlns="http://www.w3.org/2000/svg" width="{_WIDTH:.0f}" height="{height:.0f}">\n'
+        + "\n".join(parts)
+        + "\n</svg>\n"
+        "<p>Orange: ABR switches. Dashed green: trace switches. Dotted purple: scenario actions. "
+        "Red bands: stalls. Grey bands: degraded mode. Hover for details.</p>\n"
+        "</body>\n</html>\n"
+    )
+
+
+def export_session_timeline(run_dir: Path, output_path: Path | None = None, axis: str = "session") -> Path:
+    """Write the HTML timeline for one run and return its path."""
+    run_dir = Path(run_dir)
+    output_path = Path(output_path) if output_path is not None else run_dir / TIMELINE_HTML_NAME
+    output_path.parent.mkdir(parents=True, exist_ok=True)
+    output_path.write_text(render_timeline_html(build_session_timeline(run_dir, axis=axis)), encoding="utf-8")
+    return output_path
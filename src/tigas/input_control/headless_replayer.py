@@ -9,11 +9,96 @@ from __future__ import annotations
 import csv
 import json
 import math
-from dataclasses import dataclass
+import os
+from dataclasses import dataclass, replace
 
 from tigas.shared.types import UplinkDatagram
 
 
+Quaternion = tuple[float, float, float, float]
+
+
+def _matrix_to_quaternion(matrix: list[float]) -> Quaternion:
+    """Convert the rotation block of a row-major 4x4 matrix to (w, x, y, z)."""
+    m00, m01, m02 = matrix[0], matrix[1], matrix[2]
+    m10, m11, m12 = matrix[4], matrix[5], matrix[6]
+    m20, m21, m22 = matrix[8], matrix[9], matrix[10]
+    trace = m00 + m11 + m22
+    if trace > 0.0:
+        scale = math.sqrt(trace + 1.0) * 2.0
+        quat = (0.25 * scale, (m21 - m12) / scale, (m02 - m20) / scale, (m10 - m01) / scale)
+    elif m00 > m11 and m00 > m22:
+        scale = math.sqrt(1.0 + m00 - m11 - m22) * 2.0
+        quat = ((m21 - m12) / scale, 0.25 * scale, (m01 + m10) / scale, (m02 + m20) / scale)
+    elif m11 > m22:
+        scale = math.sqrt(1.0 + m11 - m00 - m22) * 2.0
+        quat = ((m02 - m20) / scale, (m01 + m10) / scale, 0.25 * scale, (m12 + m21) / scale)
+    else:
+        scale = math.sqrt(1.0 + m22 - m00 - m11) * 2.0
+        quat = ((m10 - m01) / scale, (m02 + m20) / scale, (m12 + m21) / scale, 0.25 * scale)
+    norm = math.sqrt(sum(component * component for component in quat))
+    return tuple(component / norm for component in quat)  # type: ignore[return-value]
+
+
+def _quaternion_to_rotation(quat: Quaternion) -> list[float]:
+    """Return a row-major 3x3 rotation matrix for a unit quaternion."""
+    w, x, y, z = quat
+    return [
+        1.0 - 2.0 * (y * y + z * z),
+        2.0 * (x * y - z * w),
+        2.0 * (x * z + y * w),
+        2.0 * (x * y + z * w),
+        1.0 - 2.0 * (x * x + z * z),
+        2.0 * (y * z - x * w),
+        2.0 * (x * z - y * w),
+        2.0 * (y * z + x * w),
+        1.0 - 2.0 * (x * x + y * y),
+    ]
+
+
+def _slerp(a: Quaternion, b: Quaternion, t: float) -> Quaternion:
+    """Spherical linear interpolation along the shortest arc."""
+    dot = sum(component_a * component_b for component_a, component_b in zip(a, b))
+    if dot < 0.0:
+        b = (-b[0], -b[1], -b[2], -b[3])
+        dot = -dot
+    if dot > 0.9995:
+        blended = tuple(component_a + t * (component_b - component_a) for component_a, component_b in zip(a, b))
+        norm = math.sqrt(sum(component * component for component in blended))
+        return tuple(component / norm for component in blended)  # type: ignore[return-value]
+    theta = math.acos(min(1.0, dot))
+    sin_theta = math.sin(theta)
+    weight_a = math.sin((1.0 - t) * theta) / sin_theta
+    weight_b = math.sin(t * theta) / sin_theta
+    return tuple(  # type: ignore[return-value]
+        weight_a * component_a + weight_b * component_b for component_a, component_b in zip(a, b)
+    )
+
+
+def interpolate_pose(matrix_a: list[float], matrix_b: list[float], t: float) -> list[float]:
+    """Interpolate two camera matrices: slerp rotation, lerp translation."""
+    rotation = _quaternion_to_rotation(_slerp(_matrix_to_quaternion(matrix_a), _matrix_to_quaternion(matrix_b), t))
+    translation = [matrix_a[i] + t * (matrix_b[i] - matrix_a[i]) for i in (3, 7, 11)]
+    return [
+        rotation[0],
+        rotation[1],
+        rotation[2],
+        translation[0],
+        rotation[3],
+        rotation[4],
+        rotation[5],
+        translation[1],
+        rotation[6],
+        rotation[7],
+        rotation[8],
+        translation[2],
+        0.0,
+        0.0,
+        0.0,
+        1.0,
+    ]
+
+
 @dataclass(slots=True)
 class TraceSample:
     """Single timeline sample loaded from movement trace files."""
@@ -27,6 +112,11 @@ class TraceSample:
 class HeadlessTraceReplayer:
     """Load and replay trace data while preserving temporal ordering."""
 
+    def __init__(self) -> None:
+        self._resampled_cache: dict[tuple[str, int, float], list[TraceSample]] = {}
+        self.cache_hits = 0
+        self.cache_misses = 0
+
     @staticmethod
     def _normalize(vector: tuple[float, float, float]) -> tuple[float, float, float]:
         length = math.sqrt(vector[0] ** 2 + vector[1] ** 2 + vector[2] ** 2)
@@ -137,6 +227,64 @@ class HeadlessTraceReplayer:
             )
         return samples
 
+    def resample(self, samples: list[TraceSample], rate_hz: float) -> list[TraceSample]:
+        """Resample a trace to a fixed rate with slerp-interpolated orientation.
+
+        LOD and bitrate fields are carried over from the last source sample at
+        or before each output timestamp.
+        """
+        if len(samples) < 2 or rate_hz <= 0.0:
+            return list(samples)
+
+        step_ms = 1000.0 / rate_hz
+        start_ms = samples[0].timestamp_ms
+        end_ms = samples[-1].timestamp_ms
+        resampled: list[TraceSample] = []
+        source_idx = 0
+        output_idx = 0
+        while True:
+            timestamp = start_ms + output_idx * step_ms
+            if timestamp > end_ms + 1e-9:
+                break
+            while source_idx < len(samples) - 2 and samples[source_idx + 1].timestamp_ms <= timestamp:
+                source_idx += 1
+            before = samples[source_idx]
+            after = samples[source_idx + 1]
+            span = after.timestamp_ms - before.timestamp_ms
+            t = 0.0 if span <= 0.0 else min(1.0, max(0.0, (timestamp - before.timestamp_ms) / span))
+            carried = after if t >= 1.0 else before
+            resampled.append(
+                TraceSample(
+                    timestamp_ms=timestamp,
+                    camera_matrix_4x4=interpolate_pose(before.camera_matrix_4x4, after.camera_matrix_4x4, t),
+                    requested_lod=carried.requested_lod,
+                    target_bitrate_kbps=carried.target_bitrate_kbps,
+                )
+            )
+            output_idx += 1
+        return resampled
+
+    def load_resampled_trace(self, trace_path: str, rate_hz: float) -> list[TraceSample]:
+        """Load a trace resampled to `rate_hz`, cached per file version and rate."""
+        stat = os.stat(trace_path)
+        key = (os.path.abspath(trace_path), stat.st_mtime_ns, float(rate_hz))
+        cached = self._resampled_cache.get(key)
+        if cached is None:
+            self.cache_misses += 1
+            cached = self.resample(self.load_trace(trace_path), rate_hz)
+            self._resampled_cache[key] = cached
+        else:
+            self.cache_hits += 1
+        return list(cached)
+
+    def cache_stats(self) -> dict:
+        """Return resampled-trace cache counters."""
+        return {
+            "entries": len(self._resampled_cache),
+            "hits": self.cache_hits,
+            "misses": self.cache_misses,
+        }
+
     def load_network_trace(self, trace_path: str) -> list[int]:
         """Load a network trace CSV (or newline-separated values) as kbps samples."""
         bandwidth_kbps: list[int] = []
@@ -177,6 +325,88 @@ class HeadlessTraceReplayer:
             )
         return applied
 
+    @staticmethod
+    def parse_trace_schedule(spec: str) -> list[tuple[str, float | None]]:
+        """Parse `name[@start_ms],name[@start_ms],...` into a trace schedule.
+
+        Entries without a start time follow the previous trace back-to-back.
+        Explicit start times must increase along the schedule, since an earlier
+        switch would drop the previous trace entirely.
+        """
+        schedule: list[tuple[str, float | None]] = []
+        last_start_ms: float | None = None
+        for item in spec.split(","):
+            token = item.strip()
+            if not token:
+                continue
+            name, separator, start_text = token.rpartition("@")
+            if separator and name:
+                try:
+                    start_ms = float(start_text)
+                except ValueError:
+                    pass
+                else:
+                    if last_start_ms is not None and start_ms <= last_start_ms:
+                        raise ValueError(
+                            f"Trace schedule start times must increase: '{token}' is not after {last_start_ms:g} ms."
+                        )
+                    last_start_ms = start_ms
+                    schedule.append((name, start_ms))
+                    continue
+            schedule.append((token, None))
+        return schedule
+
+    def splice_traces(
+        self,
+        traces: list[tuple[str, list[TraceSample], float | None]],
+    ) -> tuple[list[TraceSample], list[dict]]:
+        """Join several traces into one timeline and record each switch.
+
+        Each trace is rebased to start at its switch time. A scheduled switch
+        truncates the previous trace at that time; unscheduled entries start one
+        frame interval after the previous trace ends. A scheduled switch at or
+        before the previous trace's own start would drop that trace entirely,
+        so it is rejected.
+        """
+        combined: list[TraceSample] = []
+        switches: list[dict] = []
+        previous_name: str | None = None
+        previous_start_ms = 0.0
+        cursor_ms = 0.0
+
+        for name, samples, start_ms in traces:
+            if not samples:
+                continue
+            switch_ms = cursor_ms if start_ms is None else max(0.0, float(start_ms))
+            if previous_name is not None:
+                if switch_ms <= previous_start_ms:
+                    raise ValueError(
+                        f"Trace '{name}' is scheduled at {switch_ms:g} ms, not after '{previous_name}' "
+                        f"starts at {previous_start_ms:g} ms."
+                    )
+                combined = [sample for sample in combined if sample.timestamp_ms < switch_ms]
+                switches.append(
+                    {
+                        "timestamp_ms": switch_ms,
+                        "from_trace": previous_name,
+                        "to_trace": name,
+                    }
+                )
+
+            base_ms = samples[0].timestamp_ms
+            for sample in samples:
+                combined.append(replace(sample, timestamp_ms=sample.timestamp_ms - base_ms + switch_ms))
+
+            if len(samples) > 1:
+                interval_ms = (samples[-1].timestamp_ms - base_ms) / (len(samples) - 1)
+            else:
+                interval_ms = 1000.0 / 30.0
+            cursor_ms = combined[-1].timestamp_ms + max(1.0, interval_ms)
+            previous_name = name
+            previous_start_ms = switch_ms
+
+        return combined, switches
+
     def build_datagrams(self, samples: list[TraceSample]) -> list[UplinkDatagram]:
         """Convert trace samples into canonical uplink datagrams."""
         datagrams: list[UplinkDatagram] = []
@@ -7,8 +7,52 @@ validated before entering intelligence and rendering stages.
 from __future__ import annotations
 
 import json
+import math
+from typing import get_args
 
-from tigas.shared.types import UplinkDatagram
+from tigas.shared.types import LodId, UplinkDatagram
+
+# Largest payload that fits a QUIC datagram frame on a 1280-byte minimum MTU path.
+MAX_DATAGRAM_BYTES = 1200
+MAX_SEQ_ID = 2**63 - 1
+MAX_TARGET_BITRATE_KBPS = 1_000_000
+CAMERA_MATRIX_LENGTH = 16
+MAX_LATENCY_BUDGET_MS = 60_000
+
+_FIELDS = frozenset(
+    {"seq_id", "timestamp_ms", "camera_matrix_4x4", "requested_lod", "target_bitrate_kbps"}
+)
+_OPTIONAL_FIELDS = frozenset({"latency_budget_ms", "media_time_ms"})
+_LOD_IDS = frozenset(get_args(LodId))
+
+
+class DatagramDecodeError(ValueError):
+    """Raised when an uplink payload violates the datagram contract."""
+
+
+def _reject_constant(name: str) -> float:
+    raise DatagramDecodeError(f"Non-finite number '{name}' is not allowed.")
+
+
+def _require_int(data: dict, key: str, minimum: int, maximum: int) -> int:
+    value = data[key]
+    if isinstance(value, bool) or not isinstance(value, int):
+        raise DatagramDecodeError(f"{key} must be an integer.")
+    if not minimum <= value <= maximum:
+        raise DatagramDecodeError(f"{key} out of range [{minimum}, {maximum}].")
+    return value
+
+
+def _require_number(value: object, key: str) -> float:
+    if isinstance(value, bool) or not isinstance(value, (int, float)):
+        raise DatagramDecodeError(f"{key} must be a number.")
+    try:
+        number = float(value)
+    except OverflowError:
+        raise DatagramDecodeError(f"{key} must be finite.") from None
+    if not math.isfinite(number):
+        raise DatagramDecodeError(f"{key} must be finite.")
+    return number
 
 
 class UplinkDatagramProtocol:
@@ -17,8 +61,15 @@ class UplinkDatagramProtocol:
     Current scaffold uses JSON for readability. Production implementation can
     replace this with compact binary encoding as long as field semantics remain
     compatible with `schemas/uplink_datagram.schema.json`.
+
+    Decoding is strict: payload size, field set, types, and ranges are checked
+    before any object is built, and every violation surfaces as
+    `DatagramDecodeError` so a dispatcher can drop the datagram and continue.
     """
 
+    def __init__(self) -> None:
+        self.rejected_count = 0
+
     def encode(self, datagram: UplinkDatagram) -> bytes:
         """Encode a datagram instance into transport bytes."""
         payload = {
@@ -28,15 +79,64 @@ class UplinkDatagramProtocol:
             "requested_lod": datagram.requested_lod,
             "target_bitrate_kbps": datagram.target_bitrate_kbps,
         }
+        if datagram.latency_budget_ms is not None:
+            payload["latency_budget_ms"] = datagram.latency_budget_ms
+        if datagram.media_time_ms is not None:
+            payload["media_time_ms"] = datagram.media_time_ms
         return json.dumps(payload, separators=(",", ":")).encode("utf-8")
 
     def decode(self, payload: bytes) -> UplinkDatagram:
         """Decode transport bytes into the canonical datagram object."""
-        data = json.loads(payload.decode("utf-8"))
+        if len(payload) > MAX_DATAGRAM_BYTES:
+            raise DatagramDecodeError(f"Datagram exceeds {MAX_DATAGRAM_BYTES} bytes.")
+        try:
+            data = json.loads(payload.decode("utf-8"), parse_constant=_reject_constant)
+        except DatagramDecodeError:
+            raise
+        except (UnicodeDecodeError, ValueError, RecursionError) as exc:
+            raise DatagramDecodeError(f"Malformed datagram payload: {type(exc).__name__}") from None
+
+        if not isinstance(data, dict):
+            raise DatagramDecodeError("Datagram payload must be a JSON object.")
+        if not _FIELDS <= set(data) <= _FIELDS | _OPTIONAL_FIELDS:
+            missing = sorted(_FIELDS - set(data))
+            unexpected = sorted(set(data) - _FIELDS - _OPTIONAL_FIELDS)
+            raise DatagramDecodeError(f"Datagram fields mismatch (missing={missing}, unexpected={unexpected}).")
+
+        matrix = data["camera_matrix_4x4"]
+        if not isinstance(matrix, list) or len(matrix) != CAMERA_MATRIX_LENGTH:
+            raise DatagramDecodeError(f"camera_matrix_4x4 must be a list of {CAMERA_MATRIX_LENGTH} numbers.")
+        timestamp_ms = _require_number(data["timestamp_ms"], "timestamp_ms")
+        if timestamp_ms < 0.0:
+            raise DatagramDecodeError("timestamp_ms must be non-negative.")
+        requested_lod = data["requested_lod"]
+        if not isinstance(requested_lod, str) or requested_lod not in _LOD_IDS:
+            raise DatagramDecodeError("requested_lod is not a known LOD id.")
+        latency_budget_ms = None
+        if "latency_budget_ms" in data:
+            latency_budget_ms = _require_number(data["latency_budget_ms"], "latency_budget_ms")
+            if not 0.0 < latency_budget_ms <= MAX_LATENCY_BUDGET_MS:
+                raise DatagramDecodeError(f"latency_budget_ms out of range (0, {MAX_LATENCY_BUDGET_MS:g}].")
+        media_time_ms = None
+        if "media_time_ms" in data:
+            media_time_ms = _require_number(data["media_time_ms"], "media_time_ms")
+            if media_time_ms < 0.0:
+                raise DatagramDecodeError("media_time_ms must be non-negative.")
+
         return UplinkDatagram(
-            seq_id=int(data["seq_id"]),
-            timestamp_ms=float(data["timestamp_ms"]),
-            camera_matrix_4x4=list(data["camera_matrix_4x4"]),
-            requested_lod=data["requested_lod"],
-            target_bitrate_kbps=int(data["target_bitrate_kbps"]),
+            seq_id=_require_int(data, "seq_id", 0, MAX_SEQ_ID),
+            timestamp_ms=timestamp_ms,
+            camera_matrix_4x4=[_require_number(value, "camera_matrix_4x4") for value in matrix],
+            requested_lod=requested_lod,
+            target_bitrate_kbps=_require_int(data, "target_bitrate_kbps", 1, MAX_TARGET_BITRATE_KBPS),
+            latency_budget_ms=latency_budget_ms,
+            media_time_ms=media_time_ms,
         )
+
+    def try_decode(self, payload: bytes) -> UplinkDatagram | None:
+        """Decode a payload, counting and dropping contract violations."""
+        try:
+            return self.decode(payload)
+        except DatagramDecodeError:
+            self.rejected_count += 1
+            return None
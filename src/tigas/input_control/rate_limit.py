@@ -0,0 +1,58 @@
+"""Pose uplink rate limiting.
+
+The server advertises the highest pose rate that is still useful for its
+prediction horizon and current load, and enforces it by dropping datagrams
+that arrive faster than that, so high-rate controllers cannot flood the
+control path. Dropped datagrams are counted for the run summary.
+"""
+
+from __future__ import annotations
+
+
+def advertised_pose_rate_hz(
+    prediction_horizon_ms: float,
+    cpu_load: float = 0.0,
+    samples_per_horizon: int = 4,
+    floor_hz: float = 15.0,
+    ceiling_hz: float = 240.0,
+) -> float:
+    """Return the maximum useful pose rate for a horizon and CPU load in [0, 1].
+
+    A predictor gains little from more than `samples_per_horizon` poses within
+    one horizon, and the rate is scaled down linearly with load, never below
+    `floor_hz`.
+    """
+    horizon_ms = max(1.0, prediction_horizon_ms)
+    useful_hz = samples_per_horizon * 1000.0 / horizon_ms
+    load = min(1.0, max(0.0, cpu_load))
+    return float(min(ceiling_hz, max(floor_hz, useful_hz * (1.0 - load))))
+
+
+class PoseRateLimiter:
+    """Admit pose datagrams at most once per advertised interval."""
+
+    def __init__(self, max_rate_hz: float) -> None:
+        if max_rate_hz <= 0.0:
+            raise ValueError("Pose rate limit must be positive.")
+        self.max_rate_hz = float(max_rate_hz)
+        self.min_interval_ms = 1000.0 / self.max_rate_hz
+        self.admitted_count = 0
+        self.dropped_count = 0
+        self._last_admitted_ms: float | None = None
+
+    def advertise(self) -> dict:
+        """Return the rate hint sent to clients during negotiation."""
+        return {"max_pose_rate_hz": self.max_rate_hz}
+
+    def admit(self, timestamp_ms: float) -> bool:
+        """Return whether a datagram at this sender timestamp should be processed."""
+        # A small tolerance keeps senders exactly at the limit from losing every other pose to jitter.
+        if (
+            self._last_admitted_ms is not None
+            and timestamp_ms - self._last_admitted_ms < self.min_interval_ms * 0.95
+        ):
+            self.dropped_count += 1
+            return False
+        self._last_admitted_ms = timestamp_ms
+        self.admitted_count += 1
+        return True
@@ -0,0 +1,46 @@
+"""CLI entrypoint for generating or checking the control message schema."""
+
+from __future__ import annotations
+
+import argparse
+import json
+import sys
+from pathlib import Path
+
+from tigas.input_control.schema import UPLINK_SCHEMA_PATH, uplink_datagram_schema
+
+
+def build_parser() -> argparse.ArgumentParser:
+    parser = argparse.ArgumentParser(description="Generate the TIGAS uplink datagram JSON Schema")
+    parser.add_argument("--output", default="", help=f"Write the schema here (e.g. {UPLINK_SCHEMA_PATH.name})")
+    parser.add_argument(
+        "--check",
+        action="store_true",
+        help="Exit non-zero if the checked-in schema differs from the generated one",
+    )
+    return parser
+
+
+def main() -> None:
+    args = build_parser().parse_args()
+    schema = uplink_datagram_schema()
+    rendered = json.dumps(schema, indent=2) + "\n"
+
+    if args.check:
+        with UPLINK_SCHEMA_PATH.open("r", encoding="utf-8") as handle:
+            checked_in = json.load(handle)
+        if checked_in != schema:
+            print(f"{UPLINK_SCHEMA_PATH} is out of date; regenerate with --output.", file=sys.stderr)
+            sys.exit(1)
+        print(json.dumps({"status": "ok", "schema_path": str(UPLINK_SCHEMA_PATH)}, indent=2))
+        return
+
+    if args.output:
+        output_path = Path(args.output)
+        output_path.parent.mkdir(parents=True, exist_ok=True)
+        output_path.write_text(rendered, encoding="utf-8")
+    sys.stdout.write(rendered)
+
+
+if __name__ == "__main__":
+    main()
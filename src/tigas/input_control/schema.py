@@ -0,0 +1,94 @@
+"""JSON Schema generation for control message types.
+
+The uplink schema is derived from the `UplinkDatagram` dataclass and the range
+limits enforced by `UplinkDatagramProtocol`, so the browser client and Python
+tooling can validate against the same contract the decoder applies. The
+checked-in `schemas/uplink_datagram.schema.json` is generated output; a
+contract test fails when it drifts from the Python definitions.
+"""
+
+from __future__ import annotations
+
+import dataclasses
+import typing
+from pathlib import Path
+
+from tigas.input_control.protocol import (
+    CAMERA_MATRIX_LENGTH,
+    MAX_LATENCY_BUDGET_MS,
+    MAX_SEQ_ID,
+    MAX_TARGET_BITRATE_KBPS,
+)
+from tigas.shared.types import UplinkDatagram
+
+SCHEMA_DIALECT = "https://json-schema.org/draft/2020-12/schema"
+SCHEMA_BASE_ID = "https://tigas.local/schemas"
+UPLINK_SCHEMA_PATH = Path(__file__).resolve().parents[3] / "schemas" / "uplink_datagram.schema.json"
+
+# Keyword limits per field; the decoder enforces the same constants.
+_UPLINK_CONSTRAINTS: dict[str, dict] = {
+    "seq_id": {"minimum": 0, "maximum": MAX_SEQ_ID},
+    "timestamp_ms": {"minimum": 0},
+    "camera_matrix_4x4": {"minItems": CAMERA_MATRIX_LENGTH, "maxItems": CAMERA_MATRIX_LENGTH},
+    "target_bitrate_kbps": {"minimum": 1, "maximum": MAX_TARGET_BITRATE_KBPS},
+    "latency_budget_ms": {"exclusiveMinimum": 0, "maximum": MAX_LATENCY_BUDGET_MS},
+    "media_time_ms": {"minimum": 0},
+}
+
+_SCALAR_TYPES = {int: "integer", float: "number", str: "string", bool: "boolean"}
+
+
+def _type_schema(annotation: object) -> dict:
+    origin = typing.get_origin(annotation)
+    if origin is typing.Union:
+        # Optional fields are omitted from payloads rather than sent as null.
+        (item_type,) = [arg for arg in typing.get_args(annotation) if arg is not type(None)]
+        return _type_schema(item_type)
+    if origin is typing.Literal:
+        values = list(typing.get_args(annotation))
+        return {"type": _SCALAR_TYPES[type(values[0])], "enum": values}
+    if origin is list:
+        (item_type,) = typing.get_args(annotation)
+        return {"type": "array", "items": _type_schema(item_type)}
+    if annotation in _SCALAR_TYPES:
+        return {"type": _SCALAR_TYPES[annotation]}
+    raise TypeError(f"No JSON Schema mapping for annotation {annotation!r}.")
+
+
+def dataclass_schema(
+    cls: type,
+    schema_name: str,
+    title: str,
+    description: str,
+    constraints: dict[str, dict] | None = None,
+) -> dict:
+    """Build a closed object schema from a dataclass's annotated fields.
+
+    Fields without a default are required; defaulted fields are optional properties.
+    """
+    hints = typing.get_type_hints(cls)
+    constraints = constraints or {}
+    properties: dict[str, dict] = {}
+    for field in dataclasses.fields(cls):
+        properties[field.name] = {**_type_schema(hints[field.name]), **constraints.get(field.name, {})}
+    return {
+        "$schema": SCHEMA_DIALECT,
+        "$id": f"{SCHEMA_BASE_ID}/{schema_name}.schema.json",
+        "title": title,
+        "description": description,
+        "type": "object",
+        "required": [field.name for field in dataclasses.fields(cls) if field.default is dataclasses.MISSING],
+        "properties": properties,
+        "additionalProperties": False,
+    }
+
+
+def uplink_datagram_schema() -> dict:
+    """Return the JSON Schema for uplink control datagrams."""
+    return dataclass_schema(
+        UplinkDatagram,
+        schema_name="uplink_datagram",
+        title="TIGAS Uplink Datagram",
+        description="Single control datagram payload for unreliable QUIC transport.",
+        constraints=_UPLINK_CONSTRAINTS,
+    )
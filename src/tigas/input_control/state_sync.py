@@ -0,0 +1,232 @@
+"""Delta-encoded state replication for the control channel.
+
+The server keeps a small authoritative state, such as current scene
+parameters, and replicates it to the client over unreliable datagrams. Each
+message carries everything that changed since the last version the client
+acknowledged. A lost message is therefore repaired by the next one, and no
+retransmission queue is needed. Deleted keys travel as `null` tombstones.
+
+The client acknowledges the version it holds. When a delta is based on a
+version the client does not have (for example after a client restart), the
+client flags a gap and asks for a resync. The server replies with a full
+snapshot under a fresh version, and ignores acks older than that version: an
+ack that was still in flight when the resync was requested names state the
+client no longer holds. Messages also echo the highest uplink pose `seq_id` the server has
+applied, so the client learns which pose its frames answer without a separate
+ack stream.
+
+Messages are JSON like uplink datagrams and must fit in `MAX_DATAGRAM_BYTES`.
+Decoding is strict and raises `DatagramDecodeError`.
+"""
+
+from __future__ import annotations
+
+import json
+from dataclasses import dataclass, field
+
+from tigas.input_control.protocol import MAX_DATAGRAM_BYTES, DatagramDecodeError
+
+STATE_SNAPSHOT = "snapshot"
+STATE_DELTA = "delta"
+_MESSAGE_TYPE = "state_sync"
+_ACK_TYPE = "state_ack"
+
+
+def _encode(payload: dict) -> bytes:
+    data = json.dumps(payload, separators=(",", ":"), sort_keys=True).encode("utf-8")
+    if len(data) > MAX_DATAGRAM_BYTES:
+        raise ValueError(f"State sync message of {len(data)} bytes exceeds {MAX_DATAGRAM_BYTES} bytes.")
+    return data
+
+
+def _decode(payload: bytes, message_type: str, fields: set[str]) -> dict:
+    if len(payload) > MAX_DATAGRAM_BYTES:
+        raise DatagramDecodeError(f"Datagram exceeds {MAX_DATAGRAM_BYTES} bytes.")
+    try:
+        data = json.loads(payload.decode("utf-8"))
+    except (UnicodeDecodeError, ValueError, RecursionError) as exc:
+        raise DatagramDecodeError(f"Malformed state sync payload: {type(exc).__name__}") from None
+    if not isinstance(data, dict) or data.get("type") != message_type:
+        raise DatagramDecodeError(f"Payload is not a {message_type} message.")
+    if set(data) != fields | {"type"}:
+        raise DatagramDecodeError(f"{message_type} fields mismatch.")
+    return data
+
+
+def _require_version(value: object, key: str) -> int:
+    if isinstance(value, bool) or not isinstance(value, int) or value < 0:
+        raise DatagramDecodeError(f"{key} must be a non-negative integer.")
+    return value
+
+
+@dataclass(slots=True)
+class StateSyncMessage:
+    """Server-to-client snapshot or delta of the authoritative state."""
+
+    kind: str
+    version: int
+    base_version: int
+    changes: dict = field(default_factory=dict)
+    pose_seq: int | None = None
+
+    def encode(self) -> bytes:
+        return _encode(
+            {
+                "type": _MESSAGE_TYPE,
+                "kind": self.kind,
+                "version": self.version,
+                "base_version": self.base_version,
+                "changes": self.changes,
+                "pose_seq": self.pose_seq,
+            }
+        )
+
+    @classmethod
+    def decode(cls, payload: bytes) -> "StateSyncMessage":
+        data = _decode(payload, _MESSAGE_TYPE, {"kind", "version", "base_version", "changes", "pose_seq"})
+        if data["kind"] not in (STATE_SNAPSHOT, STATE_DELTA):
+            raise DatagramDecodeError("kind must be snapshot or delta.")
+        if not isinstance(data["changes"], dict):
+            raise DatagramDecodeError("changes must be an object.")
+        version = _require_version(data["version"], "version")
+        base_version = _require_version(data["base_version"], "base_version")
+        if base_version > version:
+            raise DatagramDecodeError("base_version must not exceed version.")
+        pose_seq = None if data["pose_seq"] is None else _require_version(data["pose_seq"], "pose_seq")
+        return cls(
+            kind=data["kind"],
+            version=version,
+            base_version=base_version,
+            changes=data["changes"],
+            pose_seq=pose_seq,
+        )
+
+
+@dataclass(slots=True)
+class StateSyncAck:
+    """Client-to-server acknowledgment of the state version it holds."""
+
+    version: int
+    resync: bool = False
+
+    def encode(self) -> bytes:
+        return _encode({"type": _ACK_TYPE, "version": self.version, "resync": self.resync})
+
+    @classmethod
+    def decode(cls, payload: bytes) -> "StateSyncAck":
+        data = _decode(payload, _ACK_TYPE, {"version", "resync"})
+        if not isinstance(data["resync"], bool):
+            raise DatagramDecodeError("resync must be a boolean.")
+        return cls(version=_require_version(data["version"], "version"), resync=data["resync"])
+
+
+class StateSyncServer:
+    """Authoritative state with per-key change versions for delta encoding."""
+
+    def __init__(self, initial: dict | None = None) -> None:
+        self._state: dict = {}
+        self._changed_at: dict[str, int] = {}
+        self.version = 0
+        self.acked_version = 0
+        self.pose_seq: int | None = None
+        self.resyncs = 0
+        self.stale_acks = 0
+        self._resync_version = 0
+        if initial:
+            self.update(initial)
+
+    @property
+    def state(self) -> dict:
+        return dict(self._state)
+
+    def update(self, changes: dict) -> int:
+        """Apply changes (a `None` value deletes the key) as one new version."""
+        self.version += 1
+        for key, value in changes.items():
+            if value is None:
+                self._state.pop(key, None)
+            else:
+                self._state[key] = value
+            self._changed_at[key] = self.version
+        return self.version
+
+    def observe_pose(self, seq_id: int) -> None:
+        """Record an applied uplink pose so outgoing messages acknowledge it."""
+        self.pose_seq = seq_id if self.pose_seq is None else max(self.pose_seq, seq_id)
+
+    def acknowledge(self, ack: StateSyncAck) -> None:
+        if ack.resync:
+            # The snapshot gets a version no pre-resync ack can carry, so late acks are recognizable.
+            self.version += 1
+            self._resync_version = self.version
+            self.acked_version = 0
+            self.resyncs += 1
+            return
+        if ack.version < self._resync_version:
+            self.stale_acks += 1
+            return
+        self.acked_version = max(self.acked_version, min(ack.version, self.version))
+
+    def next_message(self) -> StateSyncMessage:
+        """Snapshot until the client has acknowledged a version, then cumulative deltas."""
+        if self.acked_version == 0:
+            return StateSyncMessage(
+                kind=STATE_SNAPSHOT,
+                version=self.version,
+                base_version=0,
+                changes=dict(self._state),
+                pose_seq=self.pose_seq,
+            )
+        changes = {
+            key: self._state.get(key)
+            for key, changed_version in self._changed_at.items()
+            if changed_version > self.acked_version
+        }
+        return StateSyncMessage(
+            kind=STATE_DELTA,
+            version=self.version,
+            base_version=self.acked_version,
+            changes=changes,
+            pose_seq=self.pose_seq,
+        )
+
+
+class StateSyncClient:
+    """Replica that applies snapshots and deltas and detects version gaps."""
+
+    def __init__(self) -> None:
+        self.state: dict = {}
+        self.version = 0
+        self.pose_seq: int | None = None
+        self.needs_resync = False
+        self.gaps_detected = 0
+
+    def receive(self, message: StateSyncMessage) -> bool:
+        """Apply a message if it is usable; returns whether the replica changed."""
+        if message.pose_seq is not None:
+            self.pose_seq = message.pose_seq if self.pose_seq is None else max(self.pose_seq, message.pose_seq)
+        if message.kind == STATE_SNAPSHOT:
+            if message.version < self.version and not self.needs_resync:
+                return False
+            self.state = dict(message.changes)
+            self.version = message.version
+            self.needs_resync = False
+            return True
+        if message.version <= self.version:
+            return False
+        if message.base_version > self.version:
+            # Changes up to base_version never arrived here; applying this delta would diverge.
+            if not self.needs_resync:
+                self.gaps_detected += 1
+            self.needs_resync = True
+            return False
+        for key, value in message.changes.items():
+            if value is None:
+                self.state.pop(key, None)
+            else:
+                self.state[key] = value
+        self.version = message.version
+        return True
+
+    def ack(self) -> StateSyncAck:
+        return StateSyncAck(version=self.version, resync=self.needs_resync)
@@ -0,0 +1,77 @@
+"""Served-byte accounting by content, representation, and transport.
+
+Every byte a run moves is attributed to the content item, the representation
+(LOD for frames, `pose` for uplink datagrams), and the transport it used.
+Cumulative snapshots are taken at a fixed session-time interval so cost and
+efficiency comparisons can be read from run artifacts instead of being
+reconstructed from access logs.
+"""
+
+from __future__ import annotations
+
+TRANSPORT_FRAME = "frame"
+TRANSPORT_DATAGRAM = "datagram"
+
+
+def _add(bucket: dict[str, int], key: str, size_bytes: int) -> None:
+    bucket[key] = bucket.get(key, 0) + size_bytes
+
+
+class BandwidthAccounting:
+    """Aggregate byte counts and take periodic cumulative snapshots."""
+
+    def __init__(self, snapshot_interval_ms: float = 1000.0) -> None:
+        self.snapshot_interval_ms = max(1.0, float(snapshot_interval_ms))
+        self.total_bytes = 0
+        self.by_transport: dict[str, int] = {}
+        self.by_representation: dict[str, int] = {}
+        self.by_content: dict[str, int] = {}
+        self.snapshots: list[dict] = []
+        self._start_ms: float | None = None
+        self._next_snapshot_ms = 0.0
+        self._last_ms = 0.0
+
+    def _snapshot(self, elapsed_ms: float) -> dict:
+        return {
+            "elapsed_ms": elapsed_ms,
+            "total_bytes": self.total_bytes,
+            "by_transport": dict(sorted(self.by_transport.items())),
+        }
+
+    def record(
+        self,
+        timestamp_ms: float,
+        size_bytes: int,
+        transport: str,
+        representation: str,
+        content: str,
+    ) -> None:
+        """Attribute one transfer at a session timestamp."""
+        if self._start_ms is None:
+            self._start_ms = timestamp_ms
+            self._next_snapshot_ms = self.snapshot_interval_ms
+        elapsed_ms = max(0.0, timestamp_ms - self._start_ms)
+        while elapsed_ms >= self._next_snapshot_ms:
+            self.snapshots.append(self._snapshot(self._next_snapshot_ms))
+            self._next_snapshot_ms += self.snapshot_interval_ms
+        self._last_ms = max(self._last_ms, elapsed_ms)
+
+        size_bytes = max(0, int(size_bytes))
+        self.total_bytes += size_bytes
+        _add(self.by_transport, transport, size_bytes)
+        _add(self.by_representation, representation, size_bytes)
+        _add(self.by_content, content, size_bytes)
+
+    def finish(self) -> dict:
+        """Return totals and snapshots, closing with a snapshot at the last transfer."""
+        snapshots = list(self.snapshots)
+        if self._start_ms is not None and (not snapshots or snapshots[-1]["total_bytes"] != self.total_bytes):
+            snapshots.append(self._snapshot(self._last_ms))
+        return {
+            "snapshot_interval_ms": self.snapshot_interval_ms,
+            "total_bytes": self.total_bytes,
+            "by_transport": dict(sorted(self.by_transport.items())),
+            "by_representation": dict(sorted(self.by_representation.items())),
+            "by_content": dict(sorted(self.by_content.items())),
+            "snapshots": snapshots,
+        }
@@ -0,0 +1,68 @@
+"""Time-bounded session recording ("black box").
+
+The recorder keeps the last N seconds of a session's per-frame event stream in
+memory with no disk I/O. When an anomaly is detected (stall onset, runtime
+error), it writes the buffered window to a JSON artifact. Failures get full
+context without recording every frame of every healthy session.
+"""
+
+from __future__ import annotations
+
+import json
+import re
+from collections import deque
+from pathlib import Path
+
+
+class BlackBoxRecorder:
+    """Ring buffer of recent events that is dumped only on anomalies."""
+
+    def __init__(
+        self,
+        window_ms: float,
+        output_dir: Path | None = None,
+        max_dumps: int = 20,
+        file_prefix: str = "black_box",
+    ) -> None:
+        self.window_ms = max(0.0, float(window_ms))
+        self.output_dir = Path(output_dir) if output_dir is not None else None
+        self.max_dumps = max(0, int(max_dumps))
+        self.file_prefix = file_prefix
+        self._events: deque[dict] = deque()
+        self.dumps: list[dict] = []
+        self.suppressed_dumps = 0
+
+    def record(self, event: dict) -> None:
+        """Append one event (with `timestamp_ms`) and evict anything older than the window."""
+        self._events.append(event)
+        newest_ms = float(event["timestamp_ms"])
+        while self._events and newest_ms - float(self._events[0]["timestamp_ms"]) > self.window_ms:
+            self._events.popleft()
+
+    def window(self) -> list[dict]:
+        return list(self._events)
+
+    def dump(self, trigger: str, timestamp_ms: float, detail: str | None = None) -> dict | None:
+        """Persist the buffered window for an anomaly and return its metadata."""
+        if len(self.dumps) >= self.max_dumps:
+            self.suppressed_dumps += 1
+            return None
+        record = {
+            "trigger": trigger,
+            "timestamp_ms": float(timestamp_ms),
+            "detail": detail,
+            "window_ms": self.window_ms,
+            "events": self.window(),
+        }
+        metadata = {key: record[key] for key in ("trigger", "timestamp_ms", "detail")}
+        metadata["event_count"] = len(record["events"])
+        metadata["path"] = None
+        if self.output_dir is not None:
+            self.output_dir.mkdir(parents=True, exist_ok=True)
+            slug = re.sub(r"[^A-Za-z0-9_-]+", "_", trigger)
+            dump_path = self.output_dir / f"{self.file_prefix}_{len(self.dumps):03d}_{slug}.json"
+            with dump_path.open("w", encoding="utf-8") as handle:
+                json.dump(record, handle, indent=2)
+            metadata["path"] = str(dump_path)
+        self.dumps.append(metadata)
+        return metadata
@@ -0,0 +1,43 @@
+"""Latency budget accounting for annotated control datagrams.
+
+A client can attach `latency_budget_ms` to an uplink datagram to say how soon
+the frame answering it must be delivered. For every annotated datagram the
+server records the response latency and whether it met the budget. The
+per-session hit rate is the metric that low-latency evaluation is reported in.
+"""
+
+from __future__ import annotations
+
+
+class LatencyBudgetTracker:
+    """Count budget hits and misses for responses to annotated datagrams."""
+
+    def __init__(self) -> None:
+        self.annotated = 0
+        self.met = 0
+        self.worst_overrun_ms = 0.0
+        self._latencies_ms: list[float] = []
+
+    def observe(self, budget_ms: float | None, latency_ms: float) -> bool | None:
+        """Record one response; returns whether it met its budget, or None if unannotated."""
+        if budget_ms is None:
+            return None
+        self.annotated += 1
+        self._latencies_ms.append(float(latency_ms))
+        if latency_ms <= budget_ms:
+            self.met += 1
+            return True
+        self.worst_overrun_ms = max(self.worst_overrun_ms, float(latency_ms - budget_ms))
+        return False
+
+    def finish(self) -> dict:
+        return {
+            "annotated": self.annotated,
+            "met": self.met,
+            "missed": self.annotated - self.met,
+            "hit_rate": float(self.met / self.annotated) if self.annotated else None,
+            "latency_ms_mean": float(sum(self._latencies_ms) / len(self._latencies_ms))
+            if self._latencies_ms
+            else None,
+            "worst_overrun_ms": self.worst_overrun_ms,
+        }
@@ -0,0 +1,72 @@
+"""CLI entrypoint for the UDP path probe responder and client."""
+
+from __future__ import annotations
+
+import argparse
+import json
+import time
+
+from tigas.instrumentation.startup_diagnostics import bind_failure_diagnostics, unreachable_diagnostics
+from tigas.instrumentation.udp_probe import UdpProbeResponder, parse_probe_target, probe_path
+
+
+def build_parser() -> argparse.ArgumentParser:
+    parser = argparse.ArgumentParser(description="Measure baseline path RTT/loss with UDP echo probes")
+    mode = parser.add_mutually_exclusive_group(required=True)
+    mode.add_argument("--serve", action="store_true", help="Run a probe responder until interrupted")
+    mode.add_argument("--target", default="", help="host:port of a responder to probe")
+    parser.add_argument("--host", default="0.0.0.0", help="Responder bind address")
+    parser.add_argument("--port", type=int, default=4434, help="Responder UDP port")
+    parser.add_argument(
+        "--idle-timeout-s",
+        type=float,
+        default=30.0,
+        help="Print connectivity diagnostics if no client probes the responder within this time (0 disables)",
+    )
+    parser.add_argument("--count", type=int, default=20, help="Probes per burst")
+    parser.add_argument("--interval-ms", type=float, default=20.0, help="Spacing between probes")
+    parser.add_argument("--timeout-ms", type=float, default=500.0, help="Wait for late replies after the burst")
+    return parser
+
+
+def main() -> None:
+    args = build_parser().parse_args()
+    if args.serve:
+        try:
+            responder = UdpProbeResponder(host=args.host, port=args.port).start()
+        except OSError as exc:
+            diagnostics = bind_failure_diagnostics(args.host, args.port, exc)
+            print(json.dumps({"status": "bind_failed", "diagnostics": diagnostics.to_dict()}, indent=2))
+            raise SystemExit(1) from None
+        port = responder.address[1]
+        print(json.dumps({"status": "serving", "address": list(responder.address)}), flush=True)
+        started = time.monotonic()
+        idle_reported = args.idle_timeout_s <= 0
+        try:
+            while True:
+                time.sleep(1.0)
+                if not idle_reported and time.monotonic() - started >= args.idle_timeout_s:
+                    idle_reported = True
+                    if responder.echoed_count == 0:
+                        diagnostics = unreachable_diagnostics(args.host, port, args.idle_timeout_s)
+                        report = {"status": "no_client", "diagnostics": diagnostics.to_dict()}
+                        print(json.dumps(report), flush=True)
+        except KeyboardInterrupt:
+            pass
+        finally:
+            responder.stop()
+        return
+
+    host, port = parse_probe_target(args.target)
+    result = probe_path(
+        host,
+        port,
+        count=args.count,
+        interval_s=args.interval_ms / 1000.0,
+        timeout_s=args.timeout_ms / 1000.0,
+    )
+    print(json.dumps(result.to_dict(), indent=2))
+
+
+if __name__ == "__main__":
+    main()
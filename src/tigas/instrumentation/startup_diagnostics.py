@@ -0,0 +1,122 @@
+"""Startup diagnostics for "server started but the client cannot connect".
+
+When a UDP listener fails to bind, or nobody reaches it within an idle
+timeout, these helpers turn the failure into actionable guidance. They look
+up the errno, self-check the listener through a secondary socket on loopback
+and on the host's outbound interface, and add the common firewall commands for
+the port. Checking loopback and the LAN address separately tells "nothing is
+listening" apart from "something between the network and the socket drops
+the traffic".
+"""
+
+from __future__ import annotations
+
+import errno
+import socket
+import sys
+from dataclasses import asdict, dataclass, field
+
+from tigas.instrumentation.udp_probe import probe_path
+
+_WILDCARD_HOSTS = frozenset({"", "0.0.0.0"})
+_LOOPBACK_HOSTS = frozenset({"127.0.0.1", "localhost"})
+
+
+@dataclass(slots=True)
+class StartupDiagnostics:
+    """Findings and guidance for one startup or connectivity problem."""
+
+    issue: str
+    address: str
+    error: str | None = None
+    checks: dict = field(default_factory=dict)
+    hints: list[str] = field(default_factory=list)
+
+    def to_dict(self) -> dict:
+        return asdict(self)
+
+
+def outbound_address() -> str | None:
+    """Return the local address used for outbound traffic, if the host has a route."""
+    with socket.socket(socket.AF_INET, socket.SOCK_DGRAM) as route_socket:
+        try:
+            # Connecting a UDP socket only selects a route; no packet is sent.
+            route_socket.connect(("192.0.2.1", 9))
+            return route_socket.getsockname()[0]
+        except OSError:
+            return None
+
+
+def firewall_hints(port: int) -> list[str]:
+    """Commands that open a UDP port on common host firewalls."""
+    if sys.platform == "darwin":
+        return [
+            "macOS: allow incoming connections for the Python binary under System Settings > Network > Firewall.",
+        ]
+    return [
+        f"ufw: sudo ufw allow {port}/udp",
+        f"firewalld: sudo firewall-cmd --add-port={port}/udp",
+        f"iptables: sudo iptables -I INPUT -p udp --dport {port} -j ACCEPT",
+    ]
+
+
+def bind_failure_diagnostics(host: str, port: int, exc: OSError) -> StartupDiagnostics:
+    """Explain why a UDP bind failed and what to do about it."""
+    diagnostics = StartupDiagnostics(
+        issue="bind_failed",
+        address=f"{host}:{port}",
+        error=f"{type(exc).__name__}: {exc}",
+    )
+    if exc.errno == errno.EADDRINUSE:
+        diagnostics.hints += [
+            f"UDP port {port} is already in use by another process, often a previous server still running.",
+            f"Find it with: ss -lunp 'sport = :{port}'  (or lsof -nP -iUDP:{port})",
+            "Stop that process or choose another port with --port.",
+        ]
+    elif exc.errno == errno.EACCES:
+        diagnostics.hints.append(
+            f"Binding port {port} is not permitted; ports below 1024 need elevated privileges. Use a higher port."
+        )
+    elif exc.errno == errno.EADDRNOTAVAIL:
+        diagnostics.hints.append(
+            f"{host} is not an address of this host. Bind 0.0.0.0 or one of the host's interface addresses."
+        )
+    else:
+        diagnostics.hints.append("Unexpected bind error; check the host and port arguments.")
+    return diagnostics
+
+
+def unreachable_diagnostics(host: str, port: int, idle_s: float, timeout_s: float = 0.3) -> StartupDiagnostics:
+    """Self-check a running probe responder after no client reached it within `idle_s`."""
+    diagnostics = StartupDiagnostics(issue="no_client", address=f"{host}:{port}")
+    targets = {"loopback": "127.0.0.1"} if host in _WILDCARD_HOSTS | _LOOPBACK_HOSTS else {"bound": host}
+    lan_address = outbound_address()
+    if host in _WILDCARD_HOSTS and lan_address is not None and not lan_address.startswith("127."):
+        targets["lan"] = lan_address
+    for name, target in targets.items():
+        result = probe_path(target, port, count=3, interval_s=0.01, timeout_s=timeout_s)
+        diagnostics.checks[name] = {"target": f"{target}:{port}", "reachable": result.received > 0}
+
+    diagnostics.hints.append(f"No client reached {host}:{port} within {idle_s:g} s.")
+    if not any(check["reachable"] for check in diagnostics.checks.values()):
+        diagnostics.hints.append(
+            "The listener does not answer even from this host; a local firewall or security policy drops UDP."
+        )
+        diagnostics.hints += firewall_hints(port)
+        return diagnostics
+    if host in _LOOPBACK_HOSTS:
+        diagnostics.hints.append(
+            "The server is bound to loopback only; bind 0.0.0.0 so other devices can reach it."
+        )
+    elif "lan" in diagnostics.checks and not diagnostics.checks["lan"]["reachable"]:
+        diagnostics.hints.append(
+            f"Reachable on loopback but not on {lan_address}; the host firewall probably filters external UDP."
+        )
+        diagnostics.hints += firewall_hints(port)
+    else:
+        diagnostics.hints += [
+            f"The listener answers locally. Point the client at {lan_address or host}:{port} and check it is on "
+            "the same network; guest Wi-Fi and client isolation block device-to-device traffic.",
+            *firewall_hints(port),
+        ]
+    return diagnostics
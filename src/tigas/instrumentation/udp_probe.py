@@ -0,0 +1,147 @@
+"""UDP echo probe for baseline path characterization.
+
+A lightweight responder echoes fixed-size probe packets on a secondary port so
+clients can measure path RTT and loss independently of QUIC. Comparing these
+baselines against in-session measurements separates path effects from
+protocol effects in ABR analysis. Only well-formed probes are echoed, at their
+original size, so the responder cannot be used for amplification.
+"""
+
+from __future__ import annotations
+
+import socket
+import statistics
+import struct
+import threading
+import time
+from dataclasses import asdict, dataclass
+
+PROBE_MAGIC = b"TGPR"
+_PROBE_FORMAT = ">4sIQ"
+PROBE_SIZE = struct.calcsize(_PROBE_FORMAT)
+
+
+@dataclass(slots=True)
+class PathProbeResult:
+    """RTT and loss measured by one probe burst."""
+
+    target: str
+    sent: int
+    received: int
+    loss_rate: float
+    rtt_ms_min: float | None
+    rtt_ms_mean: float | None
+    rtt_ms_p95: float | None
+
+    def to_dict(self) -> dict:
+        return asdict(self)
+
+
+class UdpProbeResponder:
+    """Background UDP responder that echoes probe packets."""
+
+    def __init__(self, host: str = "0.0.0.0", port: int = 0) -> None:
+        self._socket = socket.socket(socket.AF_INET, socket.SOCK_DGRAM)
+        self._socket.bind((host, port))
+        self._socket.settimeout(0.2)
+        self._stop = threading.Event()
+        self._thread: threading.Thread | None = None
+        self.echoed_count = 0
+        self.ignored_count = 0
+        self.send_error_count = 0
+
+    @property
+    def address(self) -> tuple[str, int]:
+        return self._socket.getsockname()
+
+    def start(self) -> "UdpProbeResponder":
+        """Start echoing in a daemon thread."""
+        self._thread = threading.Thread(target=self._serve, name="tigas-udp-probe", daemon=True)
+        self._thread.start()
+        return self
+
+    def _serve(self) -> None:
+        while not self._stop.is_set():
+            try:
+                payload, sender = self._socket.recvfrom(2048)
+            except socket.timeout:
+                continue
+            except OSError:
+                break
+            if len(payload) != PROBE_SIZE or not payload.startswith(PROBE_MAGIC):
+                self.ignored_count += 1
+                continue
+            try:
+                self._socket.sendto(payload, sender)
+            except OSError:
+                # An unreachable sender (ICMP surfacing as ECONNREFUSED) must not stop the responder.
+                self.send_error_count += 1
+                continue
+            self.echoed_count += 1
+
+    def stop(self) -> None:
+        """Stop the responder and release its socket."""
+        self._stop.set()
+        if self._thread is not None:
+            self._thread.join(timeout=1.0)
+        self._socket.close()
+
+
+def parse_probe_target(target: str) -> tuple[str, int]:
+    """Split a `host:port` probe target."""
+    host, separator, port = target.rpartition(":")
+    if not separator or not host or not port.isdigit():
+        raise ValueError(f"Probe target must be host:port, got '{target}'.")
+    return host, int(port)
+
+
+def probe_path(
+    host: str,
+    port: int,
+    count: int = 20,
+    interval_s: float = 0.02,
+    timeout_s: float = 0.5,
+) -> PathProbeResult:
+    """Send a probe burst to a responder and summarize RTT and loss."""
+    if count <= 0:
+        raise ValueError("Probe count must be positive.")
+
+    rtts_ms: dict[int, float] = {}
+    with socket.socket(socket.AF_INET, socket.SOCK_DGRAM) as probe_socket:
+        probe_socket.connect((host, port))
+        probe_socket.settimeout(max(0.001, interval_s))
+
+        def drain_replies(until_ns: int) -> None:
+            while time.perf_counter_ns() < until_ns:
+                try:
+                    reply = probe_socket.recv(2048)
+                except (socket.timeout, ConnectionRefusedError):
+                    continue
+                if len(reply) != PROBE_SIZE:
+                    continue
+                magic, seq, sent_ns = struct.unpack(_PROBE_FORMAT, reply)
+                if magic == PROBE_MAGIC and seq < count and seq not in rtts_ms:
+                    rtts_ms[seq] = (time.perf_counter_ns() - sent_ns) / 1e6
+
+        for seq in range(count):
+            try:
+                probe_socket.send(struct.pack(_PROBE_FORMAT, PROBE_MAGIC, seq, time.perf_counter_ns()))
+            except ConnectionRefusedError:
+                # An ICMP port-unreachable from an earlier probe; keep counting it as loss.
+                pass
+            drain_replies(time.perf_counter_ns() + int(interval_s * 1e9))
+        deadline_ns = time.perf_counter_ns() + int(timeout_s * 1e9)
+        while len(rtts_ms) < count and time.perf_counter_ns() < deadline_ns:
+            drain_replies(min(deadline_ns, time.perf_counter_ns() + int(interval_s * 1e9)))
+
+    samples = sorted(rtts_ms.values())
+    p95 = samples[min(len(samples) - 1, int(round(0.95 * (len(samples) - 1))))] if samples else None
+    return PathProbeResult(
+        target=f"{host}:{port}",
+        sent=count,
+        received=len(samples),
+        loss_rate=1.0 - len(samples) / count,
+        rtt_ms_min=samples[0] if samples else None,
+        rtt_ms_mean=float(statistics.fmean(samples)) if samples else None,
+        rtt_ms_p95=p95,
+    )
@@ -9,9 +9,9 @@ from __future__ import annotations
 
 import json
 from collections import deque
-from dataclasses import dataclass
+from dataclasses import dataclass, field, replace
 from pathlib import Path
-from typing import Protocol
+from typing import ClassVar, Protocol
 
 
 @dataclass(slots=True)
@@ -20,6 +20,7 @@ class ClientAbrDecision:
 
     target_bitrate_kbps: int
     requested_lod: str
+    explanation: dict = field(default_factory=dict)
 
 
 class ClientAbrController(Protocol):
@@ -42,6 +43,7 @@ class AbrProfile:
     algorithm: str
     bitrates_kbps: list[int]
     lods: list[str]
+    version: str = "1"
     safety_factor: float = 0.9
     ewma_alpha: float = 0.3
     min_bitrate_kbps: int = 300
@@ -51,6 +53,18 @@ class AbrProfile:
     robustmpc_horizon: int = 3
     robustmpc_rebuffer_penalty: float = 4.3
     robustmpc_switch_penalty: float = 0.15
+    startup_probe_samples: int = 0
+    min_sample_elapsed_ms: float = 1.0
+    max_plausible_kbps: float = 0.0
+    estimators: list[str] = field(default_factory=list)
+    estimator_weights: dict[str, float] = field(default_factory=dict)
+    authoritative_estimator: str = ""
+    sliding_window_size: int = 5
+    freeze_window_ms: float = 0.0
+    degraded_entry_kbps: float = 0.0
+    degraded_exit_kbps: float = 0.0
+    degraded_bitrate_kbps: int = 64
+    degraded_lod: str = ""
 
     @classmethod
     def from_dict(cls, payload: dict) -> "AbrProfile":
@@ -64,11 +78,21 @@ class AbrProfile:
         if len(lods) != len(bitrates):
             raise ValueError("ABR profile lods length must match bitrates_kbps length.")
 
+        degraded_entry_kbps = float(payload.get("degraded_entry_kbps", 0.0))
+        degraded_exit_kbps = float(payload.get("degraded_exit_kbps", 0.0))
+        degraded_bitrate_kbps = int(payload.get("degraded_bitrate_kbps", 64))
+        if degraded_entry_kbps > 0:
+            if degraded_bitrate_kbps >= min(bitrates):
+                raise ValueError("degraded_bitrate_kbps must be below the lowest ladder bitrate.")
+            if 0 < degraded_exit_kbps < degraded_entry_kbps:
+                raise ValueError("degraded_exit_kbps must not be below degraded_entry_kbps.")
+
         return cls(
             name=str(payload.get("name", "unnamed_abr")),
             algorithm=str(payload.get("algorithm", "throughput")).lower(),
             bitrates_kbps=sorted(bitrates),
             lods=lods,
+            version=str(payload.get("version", "1")),
             safety_factor=float(payload.get("safety_factor", 0.9)),
             ewma_alpha=float(payload.get("ewma_alpha", 0.3)),
             min_bitrate_kbps=int(payload.get("min_bitrate_kbps", 300)),
@@ -78,31 +102,214 @@ class AbrProfile:
             robustmpc_horizon=int(payload.get("robustmpc_horizon", 3)),
             robustmpc_rebuffer_penalty=float(payload.get("robustmpc_rebuffer_penalty", 4.3)),
             robustmpc_switch_penalty=float(payload.get("robustmpc_switch_penalty", 0.15)),
+            startup_probe_samples=int(payload.get("startup_probe_samples", 0)),
+            min_sample_elapsed_ms=float(payload.get("min_sample_elapsed_ms", 1.0)),
+            max_plausible_kbps=float(payload.get("max_plausible_kbps", 0.0)),
+            estimators=[str(name) for name in payload.get("estimators", [])],
+            estimator_weights={
+                str(name): float(weight) for name, weight in payload.get("estimator_weights", {}).items()
+            },
+            authoritative_estimator=str(payload.get("authoritative_estimator", "")),
+            sliding_window_size=int(payload.get("sliding_window_size", 5)),
+            freeze_window_ms=float(payload.get("freeze_window_ms", 0.0)),
+            degraded_entry_kbps=degraded_entry_kbps,
+            degraded_exit_kbps=degraded_exit_kbps,
+            degraded_bitrate_kbps=degraded_bitrate_kbps,
+            degraded_lod=str(payload.get("degraded_lod", "")),
         )
 
 
+class BandwidthEstimator(Protocol):
+    """Common estimator interface consumed by the runtime loop and ABR wrappers."""
+
+    probe_samples: int
+    rejected_samples: int
+
+    @property
+    def probing(self) -> bool:
+        ...
+
+    def observe(self, delivered_bytes: int, elapsed_s: float, reference_kbps: float | None = None) -> float:
+        ...
+
+    def current(self, fallback_kbps: float) -> float:
+        ...
+
+    def snapshot(self, fallback_kbps: float) -> dict[str, float]:
+        ...
+
+
 @dataclass(slots=True)
 class ThroughputEstimator:
-    """EWMA-smoothed throughput estimator based on observed delivered payload."""
-
+    """EWMA-smoothed throughput estimator based on observed delivered payload.
+
+    With `probe_samples > 0` the estimator starts in a probing phase: samples
+    are collected without smoothing and, once enough are available, the
+    estimate jumps to their mean instead of ramping up through the EWMA.
+
+    Samples from transfers shorter than `min_sample_elapsed_s` (typical of
+    loopback tests, where send time says nothing about the path) are rejected.
+    So are samples faster than `max_plausible_kbps` when that cap is set. A
+    caller-supplied `reference_kbps` from the transport stack or client reports
+    is used in place of a rejected sample; without one, the sample is clamped
+    to `ceiling_kbps` (the ladder ceiling) when that is set, and dropped
+    otherwise.
+    """
+
+    kind: ClassVar[str] = "ewma"
     ewma_alpha: float = 0.3
+    probe_samples: int = 0
+    min_sample_elapsed_s: float = 0.001
+    max_plausible_kbps: float = 0.0
+    ceiling_kbps: float = 0.0
+    rejected_samples: int = 0
     _estimate_kbps: float | None = None
+    _probe_kbps: list[float] = field(default_factory=list)
 
-    def observe(self, delivered_bytes: int, elapsed_s: float) -> float:
+    @property
+    def probing(self) -> bool:
+        return len(self._probe_kbps) < self.probe_samples
+
+    def _sample_kbps(
+        self,
+        delivered_bytes: int,
+        elapsed_s: float,
+        reference_kbps: float | None,
+    ) -> tuple[float, bool]:
         safe_seconds = max(1e-6, elapsed_s)
         instantaneous_kbps = (max(0, delivered_bytes) * 8.0) / (safe_seconds * 1000.0)
+        implausible = 0.0 < self.max_plausible_kbps < instantaneous_kbps
+        if elapsed_s < self.min_sample_elapsed_s or implausible:
+            self.rejected_samples += 1
+            if reference_kbps is not None:
+                return float(reference_kbps), True
+            if self.ceiling_kbps > 0.0:
+                return min(instantaneous_kbps, self.ceiling_kbps), True
+            return min(instantaneous_kbps, self.max_plausible_kbps or instantaneous_kbps), False
+        return instantaneous_kbps, True
+
+    def _update(self, sample_kbps: float) -> float:
         if self._estimate_kbps is None:
-            self._estimate_kbps = instantaneous_kbps
+            self._estimate_kbps = sample_kbps
         else:
             alpha = min(1.0, max(0.0, self.ewma_alpha))
-            self._estimate_kbps = alpha * instantaneous_kbps + (1.0 - alpha) * self._estimate_kbps
+            self._estimate_kbps = alpha * sample_kbps + (1.0 - alpha) * self._estimate_kbps
         return self._estimate_kbps
 
+    def observe(self, delivered_bytes: int, elapsed_s: float, reference_kbps: float | None = None) -> float:
+        sample_kbps, accepted = self._sample_kbps(delivered_bytes, elapsed_s, reference_kbps)
+        if not accepted:
+            return self.current(sample_kbps)
+        if self.probing:
+            self._probe_kbps.append(sample_kbps)
+            if not self.probing:
+                self._estimate_kbps = sum(self._probe_kbps) / len(self._probe_kbps)
+            return self.current(sample_kbps)
+        return self._update(sample_kbps)
+
     def current(self, fallback_kbps: float) -> float:
         if self._estimate_kbps is None:
             return max(1.0, fallback_kbps)
         return max(1.0, self._estimate_kbps)
 
+    def snapshot(self, fallback_kbps: float) -> dict[str, float]:
+        """Return every estimate this estimator maintains, keyed by estimator kind."""
+        return {self.kind: self.current(fallback_kbps)}
+
+
+@dataclass(slots=True)
+class SlidingWindowEstimator(ThroughputEstimator):
+    """Harmonic mean over the last `window_size` accepted samples."""
+
+    kind: ClassVar[str] = "sliding_window"
+    window_size: int = 5
+    _window: deque = field(default_factory=deque)
+
+    def _update(self, sample_kbps: float) -> float:
+        self._window.append(max(1e-3, sample_kbps))
+        while len(self._window) > max(1, self.window_size):
+            self._window.popleft()
+        self._estimate_kbps = len(self._window) / sum(1.0 / value for value in self._window)
+        return self._estimate_kbps
+
+
+@dataclass(slots=True)
+class ReportedThroughputEstimator(ThroughputEstimator):
+    """EWMA over externally reported rates (transport stack or client reports).
+
+    Falls back to delivered-payload samples when no report accompanies an
+    observation.
+    """
+
+    kind: ClassVar[str] = "reported"
+
+    def _sample_kbps(
+        self,
+        delivered_bytes: int,
+        elapsed_s: float,
+        reference_kbps: float | None,
+    ) -> tuple[float, bool]:
+        if reference_kbps is not None:
+            return float(reference_kbps), True
+        return ThroughputEstimator._sample_kbps(self, delivered_bytes, elapsed_s, reference_kbps)
+
+
+class EnsembleEstimator:
+    """Run several estimators on the same samples and expose one authoritative estimate.
+
+    With `authoritative` set, that member drives decisions. Otherwise the
+    estimate is the weighted mean of all members (equal weights by default).
+    Every member's estimate stays available through `snapshot` so estimator
+    comparisons come from a single run.
+    """
+
+    def __init__(
+        self,
+        members: list[ThroughputEstimator],
+        weights: dict[str, float] | None = None,
+        authoritative: str = "",
+    ) -> None:
+        if not members:
+            raise ValueError("Ensemble estimator requires at least one member.")
+        self.members = {member.kind: member for member in members}
+        if len(self.members) != len(members):
+            raise ValueError("Ensemble estimator members must have distinct kinds.")
+        if authoritative and authoritative not in self.members:
+            raise ValueError(f"Authoritative estimator '{authoritative}' is not an ensemble member.")
+        self.authoritative = authoritative
+        self.weights = {kind: float((weights or {}).get(kind, 1.0)) for kind in self.members}
+        if not authoritative and sum(self.weights.values()) <= 0.0:
+            raise ValueError("Ensemble estimator weights must sum to a positive value.")
+
+    @property
+    def probe_samples(self) -> int:
+        return max(member.probe_samples for member in self.members.values())
+
+    @property
+    def probing(self) -> bool:
+        return any(member.probing for member in self.members.values())
+
+    @property
+    def rejected_samples(self) -> int:
+        return max(member.rejected_samples for member in self.members.values())
+
+    def observe(self, delivered_bytes: int, elapsed_s: float, reference_kbps: float | None = None) -> float:
+        for member in self.members.values():
+            member.observe(delivered_bytes, elapsed_s, reference_kbps)
+        return self.current(fallback_kbps=(max(0, delivered_bytes) * 8.0) / (max(1e-6, elapsed_s) * 1000.0))
+
+    def current(self, fallback_kbps: float) -> float:
+        if self.authoritative:
+            return self.members[self.authoritative].current(fallback_kbps)
+        total_weight = sum(self.weights.values())
+        weighted = sum(
+            self.weights[kind] * member.current(fallback_kbps) for kind, member in self.members.items()
+        )
+        return max(1.0, weighted / total_weight)
+
+    def snapshot(self, fallback_kbps: float) -> dict[str, float]:
+        return {kind: member.current(fallback_kbps) for kind, member in self.members.items()}
+
 
 class _BaseProfiledClientAbr:
     """Shared helpers for profile-driven ABR controllers."""
@@ -129,6 +336,28 @@ class _BaseProfiledClientAbr:
         index = self.bitrates.index(bitrate_kbps)
         return self.lods[index]
 
+    def _explain(
+        self,
+        throughput_kbps: float,
+        decode_latency_ms: float,
+        buffer_level_ms: float,
+        limited_by: str,
+        **thresholds: float,
+    ) -> dict:
+        """Describe the inputs and thresholds behind one decision."""
+        return {
+            "algorithm": self.profile.algorithm,
+            "profile": self.profile.name,
+            "version": self.profile.version,
+            "inputs": {
+                "throughput_kbps": float(throughput_kbps),
+                "decode_latency_ms": float(decode_latency_ms),
+                "buffer_level_ms": float(buffer_level_ms),
+            },
+            "thresholds": {key: float(value) for key, value in thresholds.items()},
+            "limited_by": limited_by,
+        }
+
 
 class ThroughputClientAbr(_BaseProfiledClientAbr):
     """Classic rate-based ABR with safety margin."""
@@ -139,11 +368,21 @@ class ThroughputClientAbr(_BaseProfiledClientAbr):
         decode_latency_ms: float,
         buffer_level_ms: float,
     ) -> ClientAbrDecision:
-        del decode_latency_ms
-        del buffer_level_ms
         target = self._clamp(throughput_kbps * self.profile.safety_factor)
         selected = self._select_nearest_lte(target)
-        return ClientAbrDecision(target_bitrate_kbps=selected, requested_lod=self._lod_for(selected))
+        explanation = self._explain(
+            throughput_kbps,
+            decode_latency_ms,
+            buffer_level_ms,
+            limited_by="throughput",
+            safe_target_kbps=target,
+            safety_factor=self.profile.safety_factor,
+        )
+        return ClientAbrDecision(
+            target_bitrate_kbps=selected,
+            requested_lod=self._lod_for(selected),
+            explanation=explanation,
+        )
 
 
 class BolaClientAbr(_BaseProfiledClientAbr):
@@ -155,7 +394,6 @@ class BolaClientAbr(_BaseProfiledClientAbr):
         decode_latency_ms: float,
         buffer_level_ms: float,
     ) -> ClientAbrDecision:
-        del decode_latency_ms
         safe_buffer_s = max(0.0, buffer_level_ms / 1000.0)
         best_bitrate = self.bitrates[0]
         best_score = -1e18
@@ -167,8 +405,21 @@ class BolaClientAbr(_BaseProfiledClientAbr):
                 best_score = score
                 best_bitrate = bitrate
 
-        capped = min(best_bitrate, self._select_nearest_lte(throughput_kbps * 1.05))
-        return ClientAbrDecision(target_bitrate_kbps=capped, requested_lod=self._lod_for(capped))
+        throughput_cap = self._select_nearest_lte(throughput_kbps * 1.05)
+        capped = min(best_bitrate, throughput_cap)
+        explanation = self._explain(
+            throughput_kbps,
+            decode_latency_ms,
+            buffer_level_ms,
+            limited_by="throughput_cap" if throughput_cap < best_bitrate else "buffer",
+            buffer_choice_kbps=best_bitrate,
+            throughput_cap_kbps=throughput_cap,
+        )
+        return ClientAbrDecision(
+            target_bitrate_kbps=capped,
+            requested_lod=self._lod_for(capped),
+            explanation=explanation,
+        )
 
 
 class RobustMpcClientAbr(_BaseProfiledClientAbr):
@@ -192,33 +443,94 @@ class RobustMpcClientAbr(_BaseProfiledClientAbr):
         decode_latency_ms: float,
         buffer_level_ms: float,
     ) -> ClientAbrDecision:
-        del decode_latency_ms
         self._history.append(max(1.0, throughput_kbps))
         predicted = self._predict_throughput(throughput_kbps)
 
-        best_bitrate = self.bitrates[0]
+        best_index = 0
         best_value = -1e18
+        best_rebuffer_s = 0.0
         buffer_s = max(0.0, buffer_level_ms / 1000.0)
         horizon = max(1, self.profile.robustmpc_horizon)
 
-        for bitrate in self.bitrates:
+        # (rebuffer cost, switch cost) per rung, to tell which term held the choice down.
+        costs: list[tuple[float, float]] = []
+        for index, bitrate in enumerate(self.bitrates):
             projected_download_s = (bitrate * horizon) / max(1.0, predicted)
             projected_rebuffer_s = max(0.0, projected_download_s - buffer_s)
             quality_term = float(bitrate) / float(self.bitrates[-1])
             switch_term = abs(float(bitrate - self._last_choice)) / float(self.bitrates[-1])
-            objective = (
-                quality_term
-                - self.profile.robustmpc_rebuffer_penalty * projected_rebuffer_s
-                - self.profile.robustmpc_switch_penalty * switch_term
-            )
+            rebuffer_cost = self.profile.robustmpc_rebuffer_penalty * projected_rebuffer_s
+            switch_cost = self.profile.robustmpc_switch_penalty * switch_term
+            costs.append((rebuffer_cost, switch_cost))
+            objective = quality_term - rebuffer_cost - switch_cost
             if objective > best_value:
                 best_value = objective
-                best_bitrate = bitrate
-
+                best_index = index
+                best_rebuffer_s = projected_rebuffer_s
+        best_bitrate = self.bitrates[best_index]
+
+        limited_by = "none"
+        if best_index < len(self.bitrates) - 1:
+            # The next rung up lost on whichever penalty grew more than its quality gain allowed.
+            extra_rebuffer = costs[best_index + 1][0] - costs[best_index][0]
+            extra_switch = costs[best_index + 1][1] - costs[best_index][1]
+            limited_by = "switch_penalty" if extra_switch > extra_rebuffer else "rebuffer_risk"
+
+        explanation = self._explain(
+            throughput_kbps,
+            decode_latency_ms,
+            buffer_level_ms,
+            limited_by=limited_by,
+            predicted_throughput_kbps=predicted,
+            projected_rebuffer_s=best_rebuffer_s,
+            objective=best_value,
+            previous_choice_kbps=self._last_choice,
+        )
         self._last_choice = best_bitrate
         return ClientAbrDecision(
             target_bitrate_kbps=best_bitrate,
             requested_lod=self._lod_for(best_bitrate),
+            explanation=explanation,
+        )
+
+
+class StartupProbingClientAbr(_BaseProfiledClientAbr):
+    """Serve the lowest rung while the throughput estimator is probing.
+
+    Once the estimator leaves its probing phase, decisions are delegated to the
+    wrapped controller, which then starts from the probed throughput.
+    """
+
+    def __init__(
+        self,
+        profile: AbrProfile,
+        inner: ClientAbrController,
+        estimator: BandwidthEstimator,
+    ) -> None:
+        super().__init__(profile)
+        self.inner = inner
+        self.estimator = estimator
+
+    def decide(
+        self,
+        throughput_kbps: float,
+        decode_latency_ms: float,
+        buffer_level_ms: float,
+    ) -> ClientAbrDecision:
+        if not self.estimator.probing:
+            return self.inner.decide(throughput_kbps, decode_latency_ms, buffer_level_ms)
+        selected = self.bitrates[0]
+        explanation = self._explain(
+            throughput_kbps,
+            decode_latency_ms,
+            buffer_level_ms,
+            limited_by="startup_probe",
+            probe_samples=self.profile.startup_probe_samples,
+        )
+        return ClientAbrDecision(
+            target_bitrate_kbps=selected,
+            requested_lod=self._lod_for(selected),
+            explanation=explanation,
         )
 
 
@@ -248,13 +560,114 @@ def load_abr_profile(profile_path: Path) -> AbrProfile:
     return AbrProfile.from_dict(payload)
 
 
-def build_client_abr_controller(profile: AbrProfile) -> ClientAbrController:
-    """Build concrete ABR controller from profile algorithm id."""
+def _parse_rung(spec: str, rung_count: int) -> int | None:
+    text = spec.strip().lower()
+    if not text:
+        return None
+    digits = text[1:] if text.startswith("p") else text
+    if not digits.isdigit() or int(digits) >= rung_count:
+        raise ValueError(f"Profile bound '{spec}' must be p0..p{rung_count - 1} for this ladder.")
+    return int(digits)
+
+
+def restrict_ladder(profile: AbrProfile, min_profile: str = "", max_profile: str = "") -> AbrProfile:
+    """Return a copy of the profile limited to rungs `min_profile`..`max_profile`.
+
+    Bounds name ladder rungs as `pN` (or plain `N`), lowest bitrate first, so a
+    study can exclude p0 artifacts or cap quality without editing the ladder.
+    """
+    rung_count = len(profile.bitrates_kbps)
+    low = _parse_rung(min_profile, rung_count)
+    high = _parse_rung(max_profile, rung_count)
+    if low is None and high is None:
+        return profile
+    low = 0 if low is None else low
+    high = rung_count - 1 if high is None else high
+    if low > high:
+        raise ValueError(f"Minimum profile p{low} is above maximum profile p{high}.")
+
+    bitrates = profile.bitrates_kbps[low : high + 1]
+    return replace(
+        profile,
+        bitrates_kbps=bitrates,
+        lods=profile.lods[low : high + 1],
+        min_bitrate_kbps=max(profile.min_bitrate_kbps, bitrates[0]),
+        max_bitrate_kbps=min(profile.max_bitrate_kbps, bitrates[-1]),
+    )
+
+
+_ESTIMATOR_KINDS: dict[str, type[ThroughputEstimator]] = {
+    ThroughputEstimator.kind: ThroughputEstimator,
+    SlidingWindowEstimator.kind: SlidingWindowEstimator,
+    ReportedThroughputEstimator.kind: ReportedThroughputEstimator,
+}
+
+
+# Default plausibility cap as a multiple of the top rung: well above any path the
+# ladder was designed for, far below raw frames timed over a loopback frame interval.
+PLAUSIBLE_KBPS_PER_TOP_RUNG = 10.0
+
+
+def build_throughput_estimator(profile: AbrProfile) -> BandwidthEstimator:
+    """Build the throughput estimator (or ensemble) configured by a profile.
+
+    Unless the profile sets `max_plausible_kbps`, samples above
+    `PLAUSIBLE_KBPS_PER_TOP_RUNG` times the top rung are treated as loopback
+    artifacts and replaced by the reference rate or the ladder ceiling
+    (`max_bitrate_kbps`, or the top rung if that is higher).
+    """
+    top_kbps = float(max(profile.bitrates_kbps))
+    ceiling_kbps = max(top_kbps, float(profile.max_bitrate_kbps))
+    max_plausible_kbps = profile.max_plausible_kbps
+    if max_plausible_kbps <= 0.0:
+        max_plausible_kbps = PLAUSIBLE_KBPS_PER_TOP_RUNG * top_kbps
+    common = {
+        "ewma_alpha": profile.ewma_alpha,
+        "probe_samples": max(0, profile.startup_probe_samples),
+        "min_sample_elapsed_s": max(0.0, profile.min_sample_elapsed_ms) / 1000.0,
+        "max_plausible_kbps": max_plausible_kbps,
+        "ceiling_kbps": ceiling_kbps,
+    }
+    if not profile.estimators:
+        return ThroughputEstimator(**common)
+
+    members: list[ThroughputEstimator] = []
+    for kind in profile.estimators:
+        estimator_cls = _ESTIMATOR_KINDS.get(kind)
+        if estimator_cls is None:
+            raise ValueError(f"Unsupported throughput estimator '{kind}'.")
+        if estimator_cls is SlidingWindowEstimator:
+            members.append(SlidingWindowEstimator(window_size=profile.sliding_window_size, **common))
+        else:
+            members.append(estimator_cls(**common))
+    if len(members) == 1:
+        return members[0]
+    return EnsembleEstimator(
+        members,
+        weights=profile.estimator_weights,
+        authoritative=profile.authoritative_estimator,
+    )
+
+
+def build_client_abr_controller(
+    profile: AbrProfile,
+    estimator: BandwidthEstimator | None = None,
+) -> ClientAbrController:
+    """Build concrete ABR controller from profile algorithm id.
+
+    Passing the run's estimator enables the profile's startup probing phase.
+    """
     algorithm = profile.algorithm.lower()
+    controller: ClientAbrController
     if algorithm == "throughput":
-        return ThroughputClientAbr(profile)
-    if algorithm == "bola":
-        return BolaClientAbr(profile)
-    if algorithm == "robustmpc":
-        return RobustMpcClientAbr(profile)
-    raise ValueError(f"Unsupported ABR algorithm '{profile.algorithm}'.")
+        controller = ThroughputClientAbr(profile)
+    elif algorithm == "bola":
+        controller = BolaClientAbr(profile)
+    elif algorithm == "robustmpc":
+        controller = RobustMpcClientAbr(profile)
+    else:
+        raise ValueError(f"Unsupported ABR algorithm '{profile.algorithm}'.")
+
+    if estimator is not None and estimator.probe_samples > 0:
+        return StartupProbingClientAbr(profile, controller, estimator)
+    return controller
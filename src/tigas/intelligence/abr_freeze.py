@@ -0,0 +1,31 @@
+"""ABR freeze windows around startup and seek events.
+
+Throughput right after startup or a seek is dominated by transients (empty
+buffers, cold connections), so switching on it produces spurious quality
+drops. A freeze window holds the previous client decision for a configurable
+time after each such event.
+"""
+
+from __future__ import annotations
+
+
+class AbrFreezeWindow:
+    """Track startup/seek notifications and report whether ABR is frozen."""
+
+    def __init__(self, window_ms: float) -> None:
+        self.window_ms = max(0.0, float(window_ms))
+        self._events: list[tuple[float, str]] = []
+        self.frozen_decisions = 0
+
+    def notify(self, event: str, timestamp_ms: float) -> None:
+        """Record a startup or seek notification at a session timestamp."""
+        self._events.append((float(timestamp_ms), event))
+
+    def active_event(self, timestamp_ms: float) -> str | None:
+        """Return the event whose freeze window covers this timestamp, if any."""
+        if self.window_ms <= 0.0:
+            return None
+        for event_ms, event in reversed(self._events):
+            if event_ms <= timestamp_ms < event_ms + self.window_ms:
+                return event
+        return None
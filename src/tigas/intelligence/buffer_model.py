@@ -0,0 +1,53 @@
+"""Virtual client buffer model.
+
+Estimates the client playout buffer without client reports: each delivered
+frame or chunk credits its media duration, and elapsed delivery time drains
+the buffer. Buffer-aware ABR algorithms read `level_ms` from this model so they
+work with minimal clients, and the offline simulator uses the same model so
+both paths agree on buffer dynamics.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+
+
+@dataclass(slots=True)
+class VirtualBufferModel:
+    """Credit/drain playout buffer estimate for one session."""
+
+    initial_ms: float = 2000.0
+    max_ms: float = 6000.0
+    level_ms: float = -1.0
+    stall_ms_total: float = 0.0
+    overflow_ms_total: float = 0.0
+    empty_count: int = 0
+
+    def __post_init__(self) -> None:
+        if self.max_ms <= 0.0:
+            raise ValueError("Buffer capacity must be positive.")
+        if self.level_ms < 0.0:
+            self.level_ms = min(max(0.0, self.initial_ms), self.max_ms)
+
+    @property
+    def empty(self) -> bool:
+        return self.level_ms <= 0.0
+
+    def credit(self, media_ms: float) -> float:
+        """Add delivered media duration and return the overflow discarded at capacity."""
+        self.level_ms += max(0.0, media_ms)
+        overflow_ms = max(0.0, self.level_ms - self.max_ms)
+        self.level_ms = min(self.level_ms, self.max_ms)
+        self.overflow_ms_total += overflow_ms
+        return overflow_ms
+
+    def drain(self, elapsed_ms: float) -> float:
+        """Play out wall-clock time and return the portion spent stalled."""
+        elapsed_ms = max(0.0, elapsed_ms)
+        played_ms = min(self.level_ms, elapsed_ms)
+        self.level_ms -= played_ms
+        stall_ms = elapsed_ms - played_ms
+        self.stall_ms_total += stall_ms
+        if self.empty:
+            self.empty_count += 1
+        return stall_ms
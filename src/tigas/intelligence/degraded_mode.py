@@ -0,0 +1,72 @@
+"""Degraded "radio" mode below the lowest ladder rung.
+
+Mobility traces include tunnels where even p0 video cannot be delivered. When
+a profile enables degraded mode, the ABR falls to a fixed low-rate
+representation (audio plus the lowest-rate thumbnail tiles) once estimated
+throughput drops below `entry_kbps`. It returns to the ladder only after
+throughput recovers to `exit_kbps`. The gap between the two thresholds keeps
+it from flapping at the boundary. Every stay in degraded mode is recorded as
+an interval for the session timeline.
+"""
+
+from __future__ import annotations
+
+from tigas.intelligence.abr_client import AbrProfile
+
+
+class DegradedModeController:
+    """Hysteresis switch between the ABR ladder and a degraded representation."""
+
+    def __init__(self, entry_kbps: float, exit_kbps: float, bitrate_kbps: int, lod: str) -> None:
+        self.entry_kbps = max(0.0, float(entry_kbps))
+        self.exit_kbps = max(self.entry_kbps, float(exit_kbps))
+        self.bitrate_kbps = int(max(1, bitrate_kbps))
+        self.lod = lod
+        self.intervals: list[dict] = []
+        self._open: dict | None = None
+
+    @classmethod
+    def for_profile(cls, profile: AbrProfile) -> "DegradedModeController | None":
+        """Build the controller a profile configures, or None when degraded mode is off.
+
+        The exit threshold defaults to the p0 bitrate and the LOD to the p0 LOD.
+        """
+        if profile.degraded_entry_kbps <= 0:
+            return None
+        return cls(
+            entry_kbps=profile.degraded_entry_kbps,
+            exit_kbps=profile.degraded_exit_kbps or float(profile.bitrates_kbps[0]),
+            bitrate_kbps=profile.degraded_bitrate_kbps,
+            lod=profile.degraded_lod or profile.lods[0],
+        )
+
+    @property
+    def active(self) -> bool:
+        return self._open is not None
+
+    def update(self, timestamp_ms: float, throughput_kbps: float) -> bool:
+        """Apply the thresholds to one throughput estimate and return whether degraded mode is active."""
+        if self._open is None and throughput_kbps < self.entry_kbps:
+            self._open = {
+                "start_ms": float(timestamp_ms),
+                "end_ms": float(timestamp_ms),
+                "entry_kbps": throughput_kbps,
+            }
+        elif self._open is not None and throughput_kbps >= self.exit_kbps:
+            self._open["end_ms"] = float(timestamp_ms)
+            self._open["exit_kbps"] = throughput_kbps
+            self.intervals.append(self._open)
+            self._open = None
+        elif self._open is not None:
+            self._open["end_ms"] = float(timestamp_ms)
+        return self.active
+
+    def finish(self) -> list[dict]:
+        """Close any open interval and return all degraded-mode intervals."""
+        if self._open is not None:
+            self._open["exit_kbps"] = None
+            self.intervals.append(self._open)
+            self._open = None
+        return [
+            {**interval, "duration_ms": interval["end_ms"] - interval["start_ms"]} for interval in self.intervals
+        ]
@@ -0,0 +1,90 @@
+"""Mapping from session time to playback (media) time.
+
+Server events are stamped with datagram timestamps, which follow the wall
+clock. What was on screen follows media time instead, which stops advancing
+while playback is stalled. Clients can send `media_time_ms` markers on uplink
+datagrams. When they do, those markers anchor the mapping. Otherwise it is
+derived from the inferred stall intervals: media time advances 1:1 with session
+time except inside stalls. Between anchors the mapping is piecewise linear.
+"""
+
+from __future__ import annotations
+
+import bisect
+
+from tigas.intelligence.stall_detector import StallInterval
+
+SOURCE_CLIENT_MARKERS = "client_markers"
+SOURCE_DERIVED = "derived_from_stalls"
+
+
+class MediaClock:
+    """Piecewise-linear session-to-media time mapping for one run."""
+
+    def __init__(self, start_ms: float = 0.0) -> None:
+        self.start_ms = float(start_ms)
+        self._markers: list[tuple[float, float]] = []
+        self.anchors: list[tuple[float, float]] = [(self.start_ms, 0.0)]
+        self.source = SOURCE_DERIVED
+
+    def mark(self, timestamp_ms: float, media_time_ms: float) -> None:
+        """Record a client-reported media position at a session timestamp."""
+        self._markers.append((float(timestamp_ms), float(media_time_ms)))
+
+    def finish(self, stall_intervals: list[StallInterval]) -> None:
+        """Build the anchors from client markers, or from stalls when there are none."""
+        if self._markers:
+            self.source = SOURCE_CLIENT_MARKERS
+            self.anchors = sorted(self._markers)
+            return
+        self.source = SOURCE_DERIVED
+        anchors = [(self.start_ms, 0.0)]
+        stalled_ms = 0.0
+        for stall in sorted(stall_intervals, key=lambda interval: interval.start_ms):
+            start_ms = max(stall.start_ms, anchors[-1][0])
+            end_ms = max(stall.end_ms, start_ms)
+            media_ms = start_ms - self.start_ms - stalled_ms
+            anchors += [(start_ms, media_ms), (end_ms, media_ms)]
+            stalled_ms += end_ms - start_ms
+        self.anchors = anchors
+
+    def to_media_ms(self, timestamp_ms: float) -> float:
+        """Translate a session timestamp to media time, extrapolating 1:1 outside the anchors."""
+        sessions = [session_ms for session_ms, _ in self.anchors]
+        index = bisect.bisect_right(sessions, timestamp_ms) - 1
+        if index < 0:
+            session_ms, media_ms = self.anchors[0]
+            return max(0.0, media_ms - (session_ms - timestamp_ms))
+        session_ms, media_ms = self.anchors[index]
+        if index + 1 < len(self.anchors):
+            next_session_ms, next_media_ms = self.anchors[index + 1]
+            if next_session_ms > session_ms:
+                fraction = (timestamp_ms - session_ms) / (next_session_ms - session_ms)
+                return media_ms + fraction * (next_media_ms - media_ms)
+        return media_ms + (timestamp_ms - session_ms)
+
+    def to_session_ms(self, media_ms: float) -> float:
+        """Translate a media position to the earliest session timestamp that reached it."""
+        first_session_ms, first_media_ms = self.anchors[0]
+        if media_ms <= first_media_ms:
+            return first_session_ms - (first_media_ms - media_ms)
+        for (session_ms, start_media_ms), (next_session_ms, next_media_ms) in zip(self.anchors, self.anchors[1:]):
+            if start_media_ms <= media_ms <= next_media_ms and next_media_ms > start_media_ms:
+                fraction = (media_ms - start_media_ms) / (next_media_ms - start_media_ms)
+                return session_ms + fraction * (next_session_ms - session_ms)
+        last_session_ms, last_media_ms = self.anchors[-1]
+        return last_session_ms + (media_ms - last_media_ms)
+
+    def to_dict(self) -> dict:
+        return {"source": self.source, "anchors": [list(anchor) for anchor in self.anchors]}
+
+    @classmethod
+    def from_dict(cls, payload: dict) -> "MediaClock":
+        clock = cls()
+        clock.source = str(payload.get("source", SOURCE_DERIVED))
+        clock.anchors = [(float(session_ms), float(media_ms)) for session_ms, media_ms in payload["anchors"]]
+        if clock.anchors:
+            clock.start_ms = clock.anchors[0][0]
+        else:
+            clock.anchors = [(0.0, 0.0)]
+        return clock
@@ -0,0 +1,85 @@
+"""Per-second on-screen quality timeline.
+
+Buckets playback positions into fixed windows and records what the viewer
+actually saw in each one: the dominant LOD, mean delivered bitrate, frame
+count, and stalled time. This is the ground-truth series QoE models consume,
+independent of how often ABR switched within a window.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, field
+
+from tigas.intelligence.stall_detector import StallInterval
+
+
+@dataclass(slots=True)
+class QualityBucket:
+    """Aggregated playback state for one timeline window."""
+
+    index: int
+    frames: int = 0
+    bitrate_kbps_sum: float = 0.0
+    lod_counts: dict[str, int] = field(default_factory=dict)
+
+    def dominant_lod(self) -> str | None:
+        if not self.lod_counts:
+            return None
+        # Ties resolve to the LOD seen first in the window.
+        return max(self.lod_counts, key=self.lod_counts.__getitem__)
+
+
+class QualityTimeline:
+    """Accumulate playback position reports into fixed-width quality buckets."""
+
+    def __init__(self, bucket_ms: float = 1000.0) -> None:
+        self.bucket_ms = max(1.0, bucket_ms)
+        self._buckets: dict[int, QualityBucket] = {}
+        self._stall_ms: dict[int, float] = {}
+
+    def observe(self, position_ms: float, lod: str, bitrate_kbps: float) -> None:
+        """Record one displayed frame at a playback position."""
+        index = int(max(0.0, position_ms) // self.bucket_ms)
+        bucket = self._buckets.setdefault(index, QualityBucket(index=index))
+        bucket.frames += 1
+        bucket.bitrate_kbps_sum += float(bitrate_kbps)
+        bucket.lod_counts[lod] = bucket.lod_counts.get(lod, 0) + 1
+
+    def add_stall(self, position_ms: float, duration_ms: float) -> None:
+        """Record a stall that froze playback at a position.
+
+        Playback positions do not advance during a stall, so the whole duration
+        lands in the window holding the frozen position.
+        """
+        index = int(max(0.0, position_ms) // self.bucket_ms)
+        self._stall_ms[index] = self._stall_ms.get(index, 0.0) + max(0.0, duration_ms)
+
+    def _stall_ms_in(self, start_ms: float, end_ms: float, stalls: list[StallInterval]) -> float:
+        return sum(max(0.0, min(end_ms, stall.end_ms) - max(start_ms, stall.start_ms)) for stall in stalls)
+
+    def finish(self, stalls: list[StallInterval] | None = None) -> list[dict]:
+        """Return one row per window from the first to the last observed one.
+
+        `stalls` are intervals on the same axis as the observed positions; stalls
+        recorded with `add_stall` are added to their window as well.
+        """
+        if not self._buckets:
+            return []
+        stalls = stalls or []
+        rows: list[dict] = []
+        for index in range(min(self._buckets), max(self._buckets) + 1):
+            bucket = self._buckets.get(index, QualityBucket(index=index))
+            start_ms = index * self.bucket_ms
+            rows.append(
+                {
+                    "index": index,
+                    "start_ms": start_ms,
+                    "frames": bucket.frames,
+                    "lod": bucket.dominant_lod(),
+                    "lod_counts": dict(bucket.lod_counts),
+                    "bitrate_kbps_mean": bucket.bitrate_kbps_sum / bucket.frames if bucket.frames else 0.0,
+                    "stall_ms": self._stall_ms_in(start_ms, start_ms + self.bucket_ms, stalls)
+                    + self._stall_ms.get(index, 0.0),
+                }
+            )
+        return rows
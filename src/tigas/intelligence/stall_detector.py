@@ -0,0 +1,92 @@
+"""Stall inference from control cadence and buffer state.
+
+The detector infers likely playback stalls without client telemetry: a stall is
+assumed when control updates arrive with gaps much larger than the nominal
+frame interval, or when the modeled client buffer drains to zero. Consecutive
+stalled observations with the same cause merge into one interval.
+"""
+
+from __future__ import annotations
+
+import statistics
+from dataclasses import dataclass
+
+
+@dataclass(slots=True)
+class StallInterval:
+    """One inferred stall on the session timeline."""
+
+    start_ms: float
+    end_ms: float
+    cause: str
+
+    @property
+    def duration_ms(self) -> float:
+        return max(0.0, self.end_ms - self.start_ms)
+
+    def to_dict(self) -> dict:
+        return {
+            "start_ms": self.start_ms,
+            "end_ms": self.end_ms,
+            "duration_ms": self.duration_ms,
+            "cause": self.cause,
+        }
+
+
+def median_interval_ms(timestamps_ms: list[float], fallback_ms: float) -> float:
+    """Median inter-arrival gap of a control schedule, the cadence gaps are measured against.
+
+    Movement traces are sampled at their own rate (about 14 Hz for the bundled
+    ones, or `trace_rate_hz` when resampled), which is usually not the render
+    fps. Measuring against the fps interval would flag every update of a
+    slower trace as a gap.
+    """
+    gaps = [later - earlier for earlier, later in zip(timestamps_ms, timestamps_ms[1:]) if later > earlier]
+    return statistics.median(gaps) if gaps else fallback_ms
+
+
+class StallDetector:
+    """Merge per-frame stall evidence into timeline intervals."""
+
+    def __init__(self, gap_factor: float = 2.5) -> None:
+        self.gap_factor = max(1.0, gap_factor)
+        self._intervals: list[StallInterval] = []
+        self._open: StallInterval | None = None
+
+    def observe(
+        self,
+        timestamp_ms: float,
+        interval_ms: float,
+        nominal_interval_ms: float,
+        buffer_level_ms: float | None = None,
+    ) -> str | None:
+        """Record one control update and return the stall cause, if any."""
+        cause: str | None = None
+        start_ms = timestamp_ms
+        if interval_ms > self.gap_factor * max(1e-3, nominal_interval_ms):
+            cause = "gap"
+            start_ms = timestamp_ms - interval_ms + nominal_interval_ms
+        elif buffer_level_ms is not None and buffer_level_ms <= 0.0:
+            cause = "buffer_empty"
+            start_ms = timestamp_ms - interval_ms
+
+        if cause is None:
+            self._close()
+            return None
+
+        if self._open is not None and self._open.cause == cause:
+            self._open.end_ms = timestamp_ms
+        else:
+            self._close()
+            self._open = StallInterval(start_ms=start_ms, end_ms=timestamp_ms, cause=cause)
+        return cause
+
+    def _close(self) -> None:
+        if self._open is not None:
+            self._intervals.append(self._open)
+            self._open = None
+
+    def finish(self) -> list[StallInterval]:
+        """Close any open interval and return all inferred stalls."""
+        self._close()
+        return list(self._intervals)
@@ -0,0 +1,257 @@
+"""CMAF chunk boundary indexing.
+
+Parses fragmented MP4 (`.m4s`) segments into moof/mdat chunk boundaries with
+their earliest presentation times, so delivery logic can make chunk-granular
+decisions (for example pushing only the remaining chunks of a live segment).
+
+Only the boxes needed for boundaries are decoded: `moof/mfhd` for the sequence
+number and `moof/traf/tfdt` plus `trun` for presentation timing. Times are in
+track timescale ticks; the timescale lives in the init segment and is passed
+in by callers that need seconds.
+"""
+
+from __future__ import annotations
+
+import os
+import struct
+from dataclasses import dataclass, field
+from pathlib import Path
+
+_TRUN_DATA_OFFSET = 0x000001
+_TRUN_FIRST_SAMPLE_FLAGS = 0x000004
+_TRUN_SAMPLE_DURATION = 0x000100
+_TRUN_SAMPLE_SIZE = 0x000200
+_TRUN_SAMPLE_FLAGS = 0x000400
+_TRUN_SAMPLE_CTO = 0x000800
+_TFHD_BASE_DATA_OFFSET = 0x000001
+_TFHD_SAMPLE_DESCRIPTION_INDEX = 0x000002
+_TFHD_DEFAULT_SAMPLE_DURATION = 0x000008
+# Far above any real fragment (a 10 s chunk at 1000 fps is 10k samples).
+MAX_TRUN_SAMPLES = 1 << 20
+
+
+@dataclass(slots=True)
+class CmafChunk:
+    """One decodable moof/mdat pair inside a CMAF segment."""
+
+    index: int
+    moof_offset: int
+    moof_size: int
+    mdat_offset: int
+    mdat_size: int
+    sequence_number: int | None
+    earliest_presentation_time: int | None
+    duration: int | None
+
+    @property
+    def end_offset(self) -> int:
+        return self.mdat_offset + self.mdat_size
+
+    def to_dict(self, timescale: int | None = None) -> dict:
+        payload = {
+            "index": self.index,
+            "moof_offset": self.moof_offset,
+            "moof_size": self.moof_size,
+            "mdat_offset": self.mdat_offset,
+            "mdat_size": self.mdat_size,
+            "end_offset": self.end_offset,
+            "sequence_number": self.sequence_number,
+            "earliest_presentation_time": self.earliest_presentation_time,
+            "duration": self.duration,
+        }
+        if timescale and self.earliest_presentation_time is not None:
+            payload["earliest_presentation_time_s"] = self.earliest_presentation_time / timescale
+        return payload
+
+
+@dataclass(slots=True)
+class CmafSegmentIndex:
+    """Chunk index for one segment file."""
+
+    path: str
+    size_bytes: int
+    chunks: list[CmafChunk] = field(default_factory=list)
+
+    def chunk_at_offset(self, byte_offset: int) -> CmafChunk | None:
+        """Return the chunk containing a byte offset, if any."""
+        for chunk in self.chunks:
+            if chunk.moof_offset <= byte_offset < chunk.end_offset:
+                return chunk
+        return None
+
+    def to_dict(self, timescale: int | None = None) -> dict:
+        return {
+            "path": self.path,
+            "size_bytes": self.size_bytes,
+            "chunks": [chunk.to_dict(timescale) for chunk in self.chunks],
+        }
+
+
+def _iter_boxes(data: bytes, start: int, end: int):
+    """Yield (type, box_offset, header_size, box_size) for boxes in a range."""
+    offset = start
+    while offset + 8 <= end:
+        size, box_type = struct.unpack_from(">I4s", data, offset)
+        header_size = 8
+        if size == 1:
+            if offset + 16 > end:
+                raise ValueError(f"Truncated largesize box at offset {offset}.")
+            size = struct.unpack_from(">Q", data, offset + 8)[0]
+            header_size = 16
+        elif size == 0:
+            size = end - offset
+        if size < header_size or offset + size > end:
+            raise ValueError(f"Invalid box size {size} at offset {offset}.")
+        yield box_type.decode("latin-1"), offset, header_size, size
+        offset += size
+
+
+def _unpack(fmt: str, data: bytes, offset: int, end: int) -> int:
+    """Read one field that must lie inside the enclosing box."""
+    if offset + struct.calcsize(fmt) > end:
+        raise ValueError(f"Truncated box body at offset {offset}.")
+    return struct.unpack_from(fmt, data, offset)[0]
+
+
+def _parse_traf(data: bytes, start: int, end: int) -> tuple[int | None, int | None]:
+    """Return (earliest presentation time, duration) for one track fragment."""
+    base_decode_time: int | None = None
+    default_duration = 0
+    earliest: int | None = None
+    # Decode time runs on across truns: a second trun continues where the first ended.
+    decode_time = 0
+
+    for box_type, offset, header_size, size in _iter_boxes(data, start, end):
+        body = offset + header_size
+        box_end = offset + size
+        if box_type == "tfhd":
+            flags = _unpack(">I", data, body, box_end) & 0xFFFFFF
+            cursor = body + 8
+            if flags & _TFHD_BASE_DATA_OFFSET:
+                cursor += 8
+            if flags & _TFHD_SAMPLE_DESCRIPTION_INDEX:
+                cursor += 4
+            if flags & _TFHD_DEFAULT_SAMPLE_DURATION:
+                default_duration = _unpack(">I", data, cursor, box_end)
+        elif box_type == "tfdt":
+            version = _unpack(">B", data, body, box_end)
+            base_decode_time = _unpack(">Q" if version == 1 else ">I", data, body + 4, box_end)
+        elif box_type == "trun":
+            version_flags = _unpack(">I", data, body, box_end)
+            version = version_flags >> 24
+            flags = version_flags & 0xFFFFFF
+            sample_count = _unpack(">I", data, body + 4, box_end)
+            cursor = body + 8
+            if flags & _TRUN_DATA_OFFSET:
+                cursor += 4
+            if flags & _TRUN_FIRST_SAMPLE_FLAGS:
+                cursor += 4
+            sample_size = 4 * bin(flags & 0xF00).count("1")
+            if cursor + sample_count * sample_size > box_end:
+                raise ValueError(f"trun at offset {offset} declares {sample_count} samples past its end.")
+            if sample_count > MAX_TRUN_SAMPLES:
+                raise ValueError(f"trun at offset {offset} declares {sample_count} samples (limit {MAX_TRUN_SAMPLES}).")
+
+            if sample_size == 0:
+                # No per-sample fields: every sample uses the default duration and a zero offset.
+                if sample_count and (earliest is None or decode_time < earliest):
+                    earliest = decode_time
+                decode_time += default_duration * sample_count
+                continue
+
+            for _ in range(sample_count):
+                duration = default_duration
+                cto = 0
+                if flags & _TRUN_SAMPLE_DURATION:
+                    duration = _unpack(">I", data, cursor, box_end)
+                    cursor += 4
+                if flags & _TRUN_SAMPLE_SIZE:
+                    cursor += 4
+                if flags & _TRUN_SAMPLE_FLAGS:
+                    cursor += 4
+                if flags & _TRUN_SAMPLE_CTO:
+                    cto = _unpack(">i" if version else ">I", data, cursor, box_end)
+                    cursor += 4
+                presentation = decode_time + cto
+                if earliest is None or presentation < earliest:
+                    earliest = presentation
+                decode_time += duration
+
+    if base_decode_time is None:
+        return None, decode_time or None
+    return base_decode_time + (earliest or 0), decode_time or None
+
+
+def _parse_moof(data: bytes, start: int, end: int) -> tuple[int | None, int | None, int | None]:
+    sequence_number: int | None = None
+    earliest: int | None = None
+    duration: int | None = None
+    for box_type, offset, header_size, size in _iter_boxes(data, start, end):
+        body = offset + header_size
+        if box_type == "mfhd":
+            sequence_number = _unpack(">I", data, body + 4, offset + size)
+        elif box_type == "traf":
+            traf_earliest, traf_duration = _parse_traf(data, body, offset + size)
+            if traf_earliest is not None and (earliest is None or traf_earliest < earliest):
+                earliest = traf_earliest
+                duration = traf_duration
+    return sequence_number, earliest, duration
+
+
+def index_cmaf_bytes(data: bytes) -> list[CmafChunk]:
+    """Index moof/mdat chunk boundaries in an in-memory CMAF segment."""
+    chunks: list[CmafChunk] = []
+    pending_moof: tuple[int, int, int | None, int | None, int | None] | None = None
+
+    for box_type, offset, header_size, size in _iter_boxes(data, 0, len(data)):
+        if box_type == "moof":
+            sequence_number, earliest, duration = _parse_moof(data, offset + header_size, offset + size)
+            pending_moof = (offset, size, sequence_number, earliest, duration)
+        elif box_type == "mdat" and pending_moof is not None:
+            moof_offset, moof_size, sequence_number, earliest, duration = pending_moof
+            chunks.append(
+                CmafChunk(
+                    index=len(chunks),
+                    moof_offset=moof_offset,
+                    moof_size=moof_size,
+                    mdat_offset=offset,
+                    mdat_size=size,
+                    sequence_number=sequence_number,
+                    earliest_presentation_time=earliest,
+                    duration=duration,
+                )
+            )
+            pending_moof = None
+    return chunks
+
+
+def index_cmaf_segment(segment_path: str) -> CmafSegmentIndex:
+    """Read one `.m4s` file and index its chunk boundaries."""
+    data = Path(segment_path).read_bytes()
+    return CmafSegmentIndex(path=str(segment_path), size_bytes=len(data), chunks=index_cmaf_bytes(data))
+
+
+class CmafIndexCache:
+    """Memoize segment indexes, invalidated by file size and mtime."""
+
+    def __init__(self) -> None:
+        self._entries: dict[str, tuple[int, int, CmafSegmentIndex]] = {}
+
+    def get(self, segment_path: str) -> CmafSegmentIndex:
+        """Return a cached index, re-parsing when the file changed."""
+        key = os.path.abspath(segment_path)
+        stat = os.stat(key)
+        cached = self._entries.get(key)
+        if cached is not None and cached[0] == stat.st_mtime_ns and cached[1] == stat.st_size:
+            return cached[2]
+
+        index = index_cmaf_segment(key)
+        self._entries[key] = (stat.st_mtime_ns, stat.st_size, index)
+        return index
+
+    def invalidate(self, segment_path: str | None = None) -> None:
+        """Drop one cached index, or all of them."""
+        if segment_path is None:
+            self._entries.clear()
+            return
+        self._entries.pop(os.path.abspath(segment_path), None)
@@ -1,11 +1,14 @@
 """Headless runtime runner.
 
 This module is runtime-focused: it drives renderer execution for a pose stream
-and reports render timing without writing evaluation artifacts.
+and reports render timing. The only files it writes are black-box anomaly dumps
+under the run's artifact directory; evaluation artifacts (frames, videos,
+metrics) are left to `tigas.evaluation`.
 """
 
 from __future__ import annotations
 
+import random
 import statistics
 import time
 from dataclasses import asdict
@@ -15,16 +18,33 @@ from typing import Callable
 import numpy as np
 
 from tigas.input_control.headless_replayer import HeadlessTraceReplayer
+from tigas.input_control.protocol import UplinkDatagramProtocol
+from tigas.input_control.rate_limit import PoseRateLimiter, advertised_pose_rate_hz
+from tigas.instrumentation.bandwidth_accounting import TRANSPORT_DATAGRAM, TRANSPORT_FRAME, BandwidthAccounting
+from tigas.instrumentation.black_box import BlackBoxRecorder
+from tigas.instrumentation.latency_budget import LatencyBudgetTracker
 from tigas.instrumentation.tc_profiles import TcProfileManager
 from tigas.intelligence.abr_client import (
-    ThroughputEstimator,
+    AbrProfile,
     build_client_abr_controller,
+    build_throughput_estimator,
     load_abr_profile,
     resolve_abr_profile,
+    restrict_ladder,
 )
+from tigas.intelligence.abr_freeze import AbrFreezeWindow
 from tigas.intelligence.abr_server import ServerAbrController
+from tigas.intelligence.buffer_model import VirtualBufferModel
+from tigas.intelligence.degraded_mode import DegradedModeController
+from tigas.intelligence.media_clock import MediaClock
+from tigas.intelligence.quality_timeline import QualityTimeline
+from tigas.intelligence.stall_detector import StallDetector, median_interval_ms
+from tigas.orchestration.scenario import ScenarioPlayer, check_profile_pins, load_scenario, pin_bounds
 from tigas.renderer.backend_cpu import CpuFallbackBackend
 from tigas.renderer.backend_gsplat import GsplatCudaBackend
+from tigas.shared.build_info import build_info
+from tigas.shared.content_paths import resolve_content_input
+from tigas.shared.feature_flags import resolve_feature_flags
 from tigas.shared.types import ExperimentConfig, RenderRequest, UplinkDatagram
 
 FrameCallback = Callable[[bytes, int, int, int, UplinkDatagram, float], None]
@@ -33,6 +53,10 @@ FrameCallback = Callable[[bytes, int, int, int, UplinkDatagram, float], None]
 class HeadlessAblationRunner:
     """Runtime renderer loop for headless execution."""
 
+    def __init__(self) -> None:
+        # Shared across runs so repeated traces in a sweep reuse resampled samples.
+        self.replayer = HeadlessTraceReplayer()
+
     def _build_renderer(self, config: ExperimentConfig, point_cloud_path: Path):
         if config.renderer_backend == "gsplat_cuda":
             return GsplatCudaBackend(
@@ -65,30 +89,34 @@ class HeadlessAblationRunner:
             "Could not resolve a point-cloud path. Set `asset_path` to a valid .ply file."
         )
 
-    @staticmethod
-    def _resolve_trace_input(trace_arg: str | None, folder: str, suffix: str) -> Path | None:
-        if not trace_arg:
-            return None
-
-        candidate = Path(trace_arg)
-        if candidate.exists():
-            return candidate
-
-        project_root = Path(__file__).resolve().parents[3]
-        folder_path = project_root / folder
-        by_name = folder_path / f"{trace_arg}{suffix}"
-        if by_name.exists():
-            return by_name
-
-        raise FileNotFoundError(
-            f"Could not resolve trace '{trace_arg}'. Checked path and {folder_path}/{trace_arg}{suffix}."
-        )
-
-    def _build_datagrams(self, config: ExperimentConfig, renderer) -> tuple[list[UplinkDatagram], str]:
-        replayer = HeadlessTraceReplayer()
-        trace_json = self._resolve_trace_input(config.trace_path, "movement_traces", ".json")
-        if trace_json and trace_json.suffix.lower() == ".json":
-            samples = replayer.load_trace(str(trace_json))
+    def _build_datagrams(
+        self,
+        config: ExperimentConfig,
+        renderer,
+    ) -> tuple[list[UplinkDatagram], str, list[dict]]:
+        replayer = self.replayer
+        trace_switches: list[dict] = []
+
+        def load_movement(trace_file: Path) -> list:
+            if config.trace_rate_hz > 0:
+                return replayer.load_resampled_trace(str(trace_file), config.trace_rate_hz)
+            return replayer.load_trace(str(trace_file))
+
+        schedule = replayer.parse_trace_schedule(config.trace_path) if config.trace_path else []
+        spliced = len(schedule) > 1 or (len(schedule) == 1 and schedule[0][1] is not None)
+        trace_json = None
+        if not spliced:
+            trace_json = resolve_content_input(config.trace_path, "movement_traces", ".json")
+
+        if spliced:
+            traces = []
+            for trace_name, start_ms in schedule:
+                trace_file = resolve_content_input(trace_name, "movement_traces", ".json")
+                traces.append((str(trace_file), load_movement(trace_file), start_ms))
+            samples, trace_switches = replayer.splice_traces(traces)
+            trace_source = "->".join(trace[0] for trace in traces)
+        elif trace_json and trace_json.suffix.lower() == ".json":
+            samples = load_movement(trace_json)
             trace_source = str(trace_json)
         else:
             orbit_radius = max(renderer.scene_radius * 2.2, 0.4)
@@ -101,7 +129,10 @@ class HeadlessAblationRunner:
             )
             trace_source = "generated_orbit"
 
-        network_trace = self._resolve_trace_input(
+        if config.trace_rate_hz > 0 and trace_source != "generated_orbit":
+            trace_source = f"{trace_source};rate={config.trace_rate_hz:g}Hz"
+
+        network_trace = resolve_content_input(
             config.network_trace_path,
             "network_traces",
             ".csv",
@@ -114,11 +145,65 @@ class HeadlessAblationRunner:
         datagrams = replayer.build_datagrams(samples)
         if config.num_frames > 0 and len(datagrams) > config.num_frames:
             datagrams = datagrams[: config.num_frames]
+        if datagrams:
+            trace_switches = [
+                switch for switch in trace_switches if switch["timestamp_ms"] <= datagrams[-1].timestamp_ms
+            ]
+
+        return datagrams, trace_source, trace_switches
+
+    @staticmethod
+    def _quality_bounds(
+        profile: AbrProfile,
+        min_profile: str,
+        max_profile: str,
+    ) -> tuple[dict | None, list[tuple[int, str]]]:
+        """Return the bitrate bounds and (kbps, LOD) rungs of a ladder slice, or (None, []) when unbounded."""
+        if not (min_profile or max_profile):
+            return None, []
+        bounded = restrict_ladder(profile, min_profile=min_profile, max_profile=max_profile)
+        bounds = {
+            "min_profile": min_profile or None,
+            "max_profile": max_profile or None,
+            "min_kbps": bounded.bitrates_kbps[0],
+            "max_kbps": bounded.bitrates_kbps[-1],
+        }
+        return bounds, list(zip(bounded.bitrates_kbps, bounded.lods))
+
+    @staticmethod
+    def _rung_lod(ladder: list[tuple[int, str]], bitrate_kbps: int) -> str:
+        """LOD of the highest rung at or below a bitrate (the lowest rung if none is)."""
+        lod = ladder[0][1]
+        for rung_kbps, rung_lod in ladder:
+            if rung_kbps <= bitrate_kbps:
+                lod = rung_lod
+        return lod
 
-        return datagrams, trace_source
+    @staticmethod
+    def _resolve_seed(config: ExperimentConfig) -> int:
+        if config.seed is not None:
+            return int(config.seed)
+        return random.SystemRandom().randrange(2**32)
 
-    def run_one(self, config: ExperimentConfig, frame_callback: FrameCallback | None = None) -> dict:
-        """Execute one runtime render pass and return timing summary."""
+    @staticmethod
+    def _seed_randomness(seed: int) -> None:
+        """Seed the process-wide Python and NumPy generators that renderers and samplers draw from."""
+        random.seed(seed)
+        np.random.seed(seed % 2**32)
+
+    def run_one(
+        self,
+        config: ExperimentConfig,
+        frame_callback: FrameCallback | None = None,
+        artifact_dir: Path | None = None,
+    ) -> dict:
+        """Execute one runtime render pass and return timing summary.
+
+        `artifact_dir` is where anomaly dumps are written; it defaults to `config.output_dir`.
+        """
+        seed = self._resolve_seed(config)
+        self._seed_randomness(seed)
+        feature_flags = resolve_feature_flags(config.feature_flags)
         point_cloud_path = self._resolve_point_cloud_path(config)
 
         renderer = self._build_renderer(config=config, point_cloud_path=point_cloud_path)
@@ -127,20 +212,68 @@ class HeadlessAblationRunner:
         scene_radius = renderer.scene_radius
         backend_name = renderer.backend_name
 
-        datagrams, trace_source = self._build_datagrams(config=config, renderer=renderer)
+        datagrams, trace_source, trace_switches = self._build_datagrams(config=config, renderer=renderer)
+        pose_rate_hz = config.max_pose_rate_hz
+        if pose_rate_hz <= 0 and config.pose_horizon_ms > 0:
+            # Headless runs have no competing load, so the advertisement follows the horizon alone.
+            pose_rate_hz = advertised_pose_rate_hz(config.pose_horizon_ms)
+        pose_limiter = PoseRateLimiter(pose_rate_hz) if pose_rate_hz > 0 else None
+        if pose_limiter is not None:
+            datagrams = [datagram for datagram in datagrams if pose_limiter.admit(datagram.timestamp_ms)]
+        if config.latency_budget_ms > 0:
+            for datagram in datagrams:
+                datagram.latency_budget_ms = config.latency_budget_ms
 
         abr_profile_name: str | None = None
         client_abr = None
         server_abr = None
         throughput_estimator = None
+        abr_freeze: AbrFreezeWindow | None = None
+        degraded_mode: DegradedModeController | None = None
+        base_profile: AbrProfile | None = None
+        quality_bounds: dict | None = None
+        bound_ladder: list[tuple[int, str]] = []
+        bound_clamps = 0
         if config.abr_profile_path:
             resolved_abr_profile = resolve_abr_profile(config.abr_profile_path)
             if resolved_abr_profile is not None:
-                profile = load_abr_profile(resolved_abr_profile)
+                base_profile = load_abr_profile(resolved_abr_profile)
+                profile = restrict_ladder(
+                    base_profile,
+                    min_profile=config.min_profile,
+                    max_profile=config.max_profile,
+                )
                 abr_profile_name = profile.name
-                client_abr = build_client_abr_controller(profile)
+                quality_bounds, bound_ladder = self._quality_bounds(
+                    base_profile,
+                    config.min_profile,
+                    config.max_profile,
+                )
+                throughput_estimator = build_throughput_estimator(profile)
+                client_abr = build_client_abr_controller(profile, estimator=throughput_estimator)
                 server_abr = ServerAbrController(frame_budget_ms=1000.0 / max(1, config.fps))
-                throughput_estimator = ThroughputEstimator(ewma_alpha=profile.ewma_alpha)
+                if feature_flags["abr_degraded_mode"]:
+                    degraded_mode = DegradedModeController.for_profile(base_profile)
+                if feature_flags["abr_freeze_window"] and profile.freeze_window_ms > 0 and datagrams:
+                    abr_freeze = AbrFreezeWindow(profile.freeze_window_ms)
+                    abr_freeze.notify("startup", datagrams[0].timestamp_ms)
+                    # Scheduled trace switches jump the viewer to a new path, like a seek.
+                    for switch in trace_switches:
+                        abr_freeze.notify("seek", switch["timestamp_ms"])
+
+        run_bounds = (quality_bounds, bound_ladder)
+        scenario_name: str | None = None
+        scenario_player: ScenarioPlayer | None = None
+        bandwidth_cap_kbps: int | None = None
+        scenario_file = resolve_content_input(config.scenario_path, "scenarios", ".json")
+        if scenario_file is not None:
+            scenario = load_scenario(scenario_file)
+            if base_profile is None and any(action.action == "pin_profile" for action in scenario.actions):
+                raise ValueError("Scenario pin_profile actions require an ABR profile.")
+            if base_profile is not None:
+                check_profile_pins(scenario, base_profile, config.min_profile, config.max_profile)
+            scenario_name = scenario.name
+            scenario_player = ScenarioPlayer(scenario, start_ms=datagrams[0].timestamp_ms if datagrams else 0.0)
 
         tc_manager = TcProfileManager() if config.enable_tc and config.tc_interface else None
         tc_status = "disabled"
@@ -150,11 +283,34 @@ class HeadlessAblationRunner:
         render_times_ms: list[float] = []
         abr_target_kbps: list[int] = []
         abr_lod_choices: list[str] = []
+        bytes_by_lod: dict[str, int] = {}
+        bandwidth = BandwidthAccounting()
+        uplink_protocol = UplinkDatagramProtocol()
+        content_name = point_cloud_path.stem
+        abr_switches: list[dict] = []
         measured_throughput_kbps: list[float] = []
-        buffer_level_ms = 2000.0
-        max_buffer_ms = 6000.0
+        rejected_throughput_samples = 0
+        estimator_estimates_kbps: dict[str, list[float]] = {}
+        buffer_model = VirtualBufferModel()
+        stall_detector = StallDetector()
+        quality_timeline = QualityTimeline()
+        latency_budget = LatencyBudgetTracker()
+        media_clock = MediaClock(start_ms=datagrams[0].timestamp_ms if datagrams else 0.0)
+        nominal_interval_ms = median_interval_ms(
+            [datagram.timestamp_ms for datagram in datagrams],
+            fallback_ms=1000.0 / max(1, config.fps),
+        )
         previous_timestamp_ms: float | None = None
         previous_render_ms = 0.0
+        previous_client_decision = None
+        previous_stall_cause: str | None = None
+        black_box: BlackBoxRecorder | None = None
+        if config.black_box_seconds > 0:
+            black_box = BlackBoxRecorder(
+                window_ms=config.black_box_seconds * 1000.0,
+                output_dir=Path(artifact_dir or config.output_dir) / "black_box",
+                file_prefix=f"black_box_s{seed}",
+            )
 
         wall_start = time.perf_counter()
         try:
@@ -165,17 +321,47 @@ class HeadlessAblationRunner:
                     frame_interval_ms = max(1.0, datagram.timestamp_ms - previous_timestamp_ms)
                 previous_timestamp_ms = datagram.timestamp_ms
 
+                if scenario_player is not None:
+                    for action in scenario_player.due(datagram.timestamp_ms):
+                        if action.action == "set_bandwidth_kbps":
+                            bandwidth_cap_kbps = int(action.params["kbps"])
+                        elif action.action == "clear_bandwidth":
+                            bandwidth_cap_kbps = None
+                        elif action.action == "pin_profile":
+                            quality_bounds, bound_ladder = self._quality_bounds(
+                                base_profile,
+                                *pin_bounds(action, base_profile, config.min_profile, config.max_profile),
+                            )
+                        elif action.action == "unpin_profile":
+                            quality_bounds, bound_ladder = run_bounds
+
                 baseline_target_kbps = int(max(1, datagram.target_bitrate_kbps))
+                if bandwidth_cap_kbps is not None:
+                    baseline_target_kbps = min(baseline_target_kbps, bandwidth_cap_kbps)
+                network_limited = bool(config.network_trace_path) or bandwidth_cap_kbps is not None
                 estimated_throughput_kbps = float(baseline_target_kbps)
+                estimates: dict[str, float] = {}
                 if throughput_estimator is not None:
                     estimated_throughput_kbps = throughput_estimator.current(baseline_target_kbps)
+                    estimates = throughput_estimator.snapshot(baseline_target_kbps)
+                    for kind, value in estimates.items():
+                        estimator_estimates_kbps.setdefault(kind, []).append(value)
 
                 if client_abr is not None and server_abr is not None:
                     client_decision = client_abr.decide(
                         throughput_kbps=estimated_throughput_kbps,
                         decode_latency_ms=previous_render_ms,
-                        buffer_level_ms=buffer_level_ms,
+                        buffer_level_ms=buffer_model.level_ms,
                     )
+                    if (
+                        abr_freeze is not None
+                        and previous_client_decision is not None
+                        and abr_freeze.active_event(datagram.timestamp_ms) is not None
+                    ):
+                        if client_decision.target_bitrate_kbps != previous_client_decision.target_bitrate_kbps:
+                            abr_freeze.frozen_decisions += 1
+                        client_decision = previous_client_decision
+                    previous_client_decision = client_decision
                     server_decision = server_abr.decide(
                         render_time_ms=previous_render_ms,
                         encode_queue_depth=0,
@@ -183,16 +369,59 @@ class HeadlessAblationRunner:
                         client_requested_lod=client_decision.requested_lod,
                         client_target_bitrate_kbps=client_decision.target_bitrate_kbps,
                     )
-                    chosen_lod = server_decision.enforced_lod
-                    chosen_target_kbps = int(max(1, server_decision.encoder_bitrate_kbps))
-                    if config.network_trace_path:
-                        chosen_target_kbps = min(chosen_target_kbps, baseline_target_kbps)
+                    if feature_flags["abr_server_guardrail"]:
+                        chosen_lod = server_decision.enforced_lod
+                        chosen_target_kbps = int(max(1, server_decision.encoder_bitrate_kbps))
+                    else:
+                        chosen_lod = client_decision.requested_lod
+                        chosen_target_kbps = int(max(1, client_decision.target_bitrate_kbps))
+                    network_capped = network_limited and chosen_target_kbps > baseline_target_kbps
+                    if network_capped:
+                        chosen_target_kbps = baseline_target_kbps
+                    # Tiny degraded frames make app-limited samples understate the link, so the
+                    # thresholds use the transport's reported rate when the run has one.
+                    degraded = degraded_mode is not None and degraded_mode.update(
+                        datagram.timestamp_ms,
+                        float(baseline_target_kbps) if network_limited else estimated_throughput_kbps,
+                    )
+                    if degraded:
+                        chosen_target_kbps, chosen_lod = degraded_mode.bitrate_kbps, degraded_mode.lod
+                    if quality_bounds is not None:
+                        # Bounds win over guardrail backoff and network caps; the run accepts stalls instead.
+                        bounded_kbps = min(
+                            max(chosen_target_kbps, quality_bounds["min_kbps"]),
+                            quality_bounds["max_kbps"],
+                        )
+                        # LODs repeat across rungs, so a clamped rate takes the LOD of the rung it lands on.
+                        bound_lods = {lod for _, lod in bound_ladder}
+                        if bounded_kbps != chosen_target_kbps or chosen_lod not in bound_lods:
+                            bounded_lod = self._rung_lod(bound_ladder, bounded_kbps)
+                        else:
+                            bounded_lod = chosen_lod
+                        if (bounded_kbps, bounded_lod) != (chosen_target_kbps, chosen_lod):
+                            bound_clamps += 1
+                        chosen_target_kbps, chosen_lod = bounded_kbps, bounded_lod
+                    if abr_target_kbps and abr_target_kbps[-1] != chosen_target_kbps:
+                        abr_switches.append(
+                            {
+                                "seq_id": datagram.seq_id,
+                                "timestamp_ms": datagram.timestamp_ms,
+                                "from_kbps": abr_target_kbps[-1],
+                                "to_kbps": chosen_target_kbps,
+                                "lod": chosen_lod,
+                                "server_reason": server_decision.reason,
+                                "network_capped": network_capped,
+                                "degraded_mode": degraded,
+                                "explanation": client_decision.explanation,
+                                "estimates_kbps": estimates,
+                            }
+                        )
                 else:
                     chosen_target_kbps = baseline_target_kbps
                     chosen_lod = datagram.requested_lod if config.default_lod == "adaptive" else config.default_lod
 
                 if tc_manager is not None:
-                    tc_rate_kbps = baseline_target_kbps if config.network_trace_path else chosen_target_kbps
+                    tc_rate_kbps = baseline_target_kbps if network_limited else chosen_target_kbps
                     if last_tc_rate_kbps != tc_rate_kbps:
                         try:
                             tc_manager.apply_rate_kbps(config.tc_interface or "", tc_rate_kbps)
@@ -213,9 +442,35 @@ class HeadlessAblationRunner:
                 frame = renderer.render(request)
                 render_ms = (time.perf_counter() - render_start) * 1000.0
                 render_times_ms.append(render_ms)
-                previous_render_ms = render_ms
+                if not config.deterministic:
+                    # Deterministic runs keep wall-clock render time out of ABR inputs.
+                    previous_render_ms = render_ms
                 abr_target_kbps.append(chosen_target_kbps)
                 abr_lod_choices.append(chosen_lod)
+                bytes_by_lod[chosen_lod] = bytes_by_lod.get(chosen_lod, 0) + len(frame.data)
+                bandwidth.record(
+                    datagram.timestamp_ms,
+                    len(uplink_protocol.encode(datagram)),
+                    transport=TRANSPORT_DATAGRAM,
+                    representation="pose",
+                    content=content_name,
+                )
+                bandwidth.record(
+                    datagram.timestamp_ms,
+                    len(frame.data),
+                    transport=TRANSPORT_FRAME,
+                    representation=chosen_lod,
+                    content=content_name,
+                )
+                if datagram.media_time_ms is not None:
+                    media_clock.mark(datagram.timestamp_ms, datagram.media_time_ms)
+                # Response latency: render time plus serializing the frame at the available bandwidth.
+                # Deterministic runs count only the serialization part so hit rates repeat.
+                serialize_ms = len(frame.data) * 8.0 / max(1.0, float(baseline_target_kbps))
+                latency_budget.observe(
+                    datagram.latency_budget_ms,
+                    serialize_ms + (0.0 if config.deterministic else render_ms),
+                )
 
                 if frame_callback is not None:
                     frame_callback(
@@ -228,16 +483,52 @@ class HeadlessAblationRunner:
                     )
 
                 if throughput_estimator is not None:
+                    rejected_before = throughput_estimator.rejected_samples
                     measured = throughput_estimator.observe(
                         delivered_bytes=len(frame.data),
                         elapsed_s=frame_interval_ms / 1000.0,
+                        reference_kbps=float(baseline_target_kbps) if network_limited else None,
                     )
+                    if network_limited:
+                        # Without a network rate every raw frame is rejected, so only count
+                        # rejections that a real measurement replaced.
+                        rejected_throughput_samples += throughput_estimator.rejected_samples - rejected_before
                     measured_throughput_kbps.append(measured)
                     frame_bits = float(len(frame.data) * 8)
                     download_time_ms = frame_bits / max(1.0, float(chosen_target_kbps))
-                    buffer_level_ms = float(
-                        np.clip(buffer_level_ms + frame_interval_ms - download_time_ms, 0.0, max_buffer_ms)
+                    buffer_model.credit(frame_interval_ms)
+                    buffer_model.drain(download_time_ms)
+
+                stall_cause = stall_detector.observe(
+                    timestamp_ms=datagram.timestamp_ms,
+                    interval_ms=frame_interval_ms,
+                    nominal_interval_ms=nominal_interval_ms,
+                    buffer_level_ms=buffer_model.level_ms if throughput_estimator is not None else None,
+                )
+                if black_box is not None:
+                    black_box.record(
+                        {
+                            "timestamp_ms": datagram.timestamp_ms,
+                            "seq_id": datagram.seq_id,
+                            "lod": chosen_lod,
+                            "target_kbps": chosen_target_kbps,
+                            "network_kbps": baseline_target_kbps,
+                            "estimates_kbps": estimates,
+                            "buffer_level_ms": buffer_model.level_ms if throughput_estimator is not None else None,
+                            "frame_interval_ms": frame_interval_ms,
+                            "render_ms": render_ms,
+                            "frame_bytes": len(frame.data),
+                            "stall_cause": stall_cause,
+                        }
                     )
+                    # Dump once per stall onset, not on every frame of an ongoing stall.
+                    if stall_cause is not None and previous_stall_cause is None:
+                        black_box.dump(f"stall_{stall_cause}", datagram.timestamp_ms)
+                previous_stall_cause = stall_cause
+        except Exception as exc:
+            if black_box is not None:
+                black_box.dump("error", previous_timestamp_ms or 0.0, detail=f"{type(exc).__name__}: {exc}")
+            raise
         finally:
             if tc_manager is not None and tc_applied and config.tc_interface:
                 try:
@@ -252,10 +543,35 @@ class HeadlessAblationRunner:
             raise RuntimeError("Headless runtime rendered zero frames.")
 
         render_times_array = np.asarray(render_times_ms, dtype=np.float64)
+        stall_intervals = stall_detector.finish()
+        degraded_intervals = degraded_mode.finish() if degraded_mode is not None else []
+        media_clock.finish(stall_intervals)
+        scenario_events = scenario_player.fired if scenario_player is not None else []
+        for event in [*abr_switches, *trace_switches, *scenario_events]:
+            event["media_time_ms"] = media_clock.to_media_ms(event["timestamp_ms"])
+        stall_rows = [
+            {**interval.to_dict(), "media_time_ms": media_clock.to_media_ms(interval.start_ms)}
+            for interval in stall_intervals
+        ]
+        # The timeline follows playback position, so frames shown after a stall land where they played.
+        for datagram, lod, target_kbps in zip(datagrams, abr_lod_choices, abr_target_kbps):
+            quality_timeline.observe(media_clock.to_media_ms(datagram.timestamp_ms), lod, target_kbps)
+        for row in stall_rows:
+            quality_timeline.add_stall(row["media_time_ms"], row["duration_ms"])
+        abr_switch_count = sum(
+            1 for previous, current in zip(abr_target_kbps, abr_target_kbps[1:]) if previous != current
+        )
         return {
             "status": "ok",
             "point_cloud_path": str(point_cloud_path),
             "trace_source": trace_source,
+            "trace_switches": trace_switches,
+            "scenario": scenario_name,
+            "scenario_events": scenario_events,
+            "scenario_pending_actions": scenario_player.pending_count if scenario_player is not None else 0,
+            "pose_rate_limit_hz": pose_limiter.max_rate_hz if pose_limiter is not None else None,
+            "pose_rate_advertisement": pose_limiter.advertise() if pose_limiter is not None else None,
+            "pose_datagrams_dropped": pose_limiter.dropped_count if pose_limiter is not None else 0,
             "network_trace_path": config.network_trace_path,
             "target_bitrate_kbps_mean": float(
                 np.mean([d.target_bitrate_kbps for d in datagrams])
@@ -264,6 +580,9 @@ class HeadlessAblationRunner:
             "abr_throughput_kbps_mean": float(np.mean(measured_throughput_kbps))
             if measured_throughput_kbps
             else None,
+            "abr_estimates_kbps_mean": {
+                kind: float(statistics.fmean(values)) for kind, values in sorted(estimator_estimates_kbps.items())
+            },
             "frames_rendered": frames_rendered,
             "resolution": {"width": config.width, "height": config.height},
             "renderer_backend": backend_name,
@@ -275,6 +594,26 @@ class HeadlessAblationRunner:
             }
             if abr_lod_choices
             else {},
+            "bytes_by_lod": dict(sorted(bytes_by_lod.items())),
+            "bandwidth_usage": bandwidth.finish(),
+            "abr_switch_count": int(abr_switch_count),
+            "abr_switches": abr_switches,
+            "abr_buffer_empty_frames": int(buffer_model.empty_count),
+            "abr_virtual_stall_ms": float(buffer_model.stall_ms_total),
+            "abr_quality_bounds": run_bounds[0],
+            "abr_bound_clamps": bound_clamps,
+            "abr_degraded_intervals": degraded_intervals,
+            "abr_degraded_time_ms": float(sum(interval["duration_ms"] for interval in degraded_intervals)),
+            "abr_frozen_decisions": abr_freeze.frozen_decisions if abr_freeze is not None else 0,
+            "black_box_dumps": black_box.dumps if black_box is not None else [],
+            "black_box_suppressed_dumps": black_box.suppressed_dumps if black_box is not None else 0,
+            "abr_rejected_throughput_samples": rejected_throughput_samples,
+            "latency_budget": latency_budget.finish(),
+            "stall_count": len(stall_intervals),
+            "stall_time_ms": float(sum(interval.duration_ms for interval in stall_intervals)),
+            "stall_intervals": stall_rows,
+            "media_clock": media_clock.to_dict(),
+            "quality_timeline": quality_timeline.finish(),
             "tc": {
                 "enabled": bool(config.enable_tc and config.tc_interface),
                 "interface": config.tc_interface,
@@ -289,7 +628,12 @@ class HeadlessAblationRunner:
             },
             "wall_time_s": wall_time_s,
             "effective_fps": float(frames_rendered / wall_time_s) if wall_time_s > 0 else 0.0,
+            "trace_cache": self.replayer.cache_stats(),
+            "seed": seed,
+            "deterministic": bool(config.deterministic),
+            "feature_flags": feature_flags,
             "config": asdict(config),
+            "build": build_info(),
         }
 
     def run_matrix(self, configs: list[ExperimentConfig]) -> list[dict]:
@@ -0,0 +1,216 @@
+"""Preflight validation for headless runs.
+
+Checks configuration, asset layout, ABR ladder consistency, and trace files
+without initializing a renderer, so experiment scripts can fail fast before
+reserving testbed time.
+"""
+
+from __future__ import annotations
+
+import json
+import shutil
+from dataclasses import asdict, dataclass
+from pathlib import Path
+from typing import get_args
+
+from tigas.input_control.headless_replayer import HeadlessTraceReplayer
+from tigas.intelligence.abr_client import (
+    AbrProfile,
+    build_client_abr_controller,
+    build_throughput_estimator,
+    load_abr_profile,
+    resolve_abr_profile,
+    restrict_ladder,
+)
+from tigas.orchestration.scenario import check_profile_pins, load_scenario
+from tigas.shared.content_paths import resolve_content_input
+from tigas.shared.types import ExperimentConfig, LodId
+
+
+@dataclass(slots=True)
+class PreflightCheck:
+    """Outcome of one preflight validation step."""
+
+    name: str
+    ok: bool
+    detail: str
+
+
+def _check_asset(config: ExperimentConfig) -> PreflightCheck:
+    if not config.asset_path:
+        return PreflightCheck("asset", False, "No asset path configured.")
+    path = Path(config.asset_path)
+    if not path.is_file():
+        return PreflightCheck("asset", False, f"Asset not found: {path}")
+
+    with path.open("rb") as handle:
+        if handle.readline().strip() != b"ply":
+            return PreflightCheck("asset", False, f"Asset is not a PLY file: {path}")
+        vertex_count = 0
+        for _ in range(4096):
+            line = handle.readline()
+            if not line:
+                break
+            if line.startswith(b"element vertex "):
+                try:
+                    vertex_count = int(line.split()[2])
+                except (ValueError, IndexError):
+                    return PreflightCheck("asset", False, f"Malformed PLY vertex element {line.strip()!r}: {path}")
+            if line.strip() == b"end_header":
+                if vertex_count <= 0:
+                    return PreflightCheck("asset", False, f"PLY declares no vertices: {path}")
+                return PreflightCheck("asset", True, f"{path} ({vertex_count} vertices)")
+    return PreflightCheck("asset", False, f"PLY header has no end_header: {path}")
+
+
+def _check_movement_trace(config: ExperimentConfig) -> PreflightCheck:
+    if not config.trace_path:
+        return PreflightCheck("movement_trace", True, "No trace configured; generated orbit will be used.")
+
+    replayer = HeadlessTraceReplayer()
+    try:
+        schedule = replayer.parse_trace_schedule(config.trace_path)
+    except ValueError as exc:
+        return PreflightCheck("movement_trace", False, str(exc))
+    details: list[str] = []
+    loaded: list[tuple[str, list, float | None]] = []
+    for trace_name, start_ms in schedule:
+        try:
+            trace_path = resolve_content_input(trace_name, "movement_traces", ".json")
+            if trace_path is None or trace_path.suffix.lower() != ".json":
+                if len(schedule) == 1:
+                    return PreflightCheck("movement_trace", True, "Trace is not JSON; generated orbit will be used.")
+                return PreflightCheck("movement_trace", False, f"Scheduled trace is not JSON: {trace_name}")
+            samples = replayer.load_trace(str(trace_path))
+        except (OSError, ValueError, KeyError, TypeError) as exc:
+            return PreflightCheck("movement_trace", False, f"{type(exc).__name__}: {exc}")
+
+        if not samples:
+            return PreflightCheck("movement_trace", False, f"Trace has no samples: {trace_path}")
+        timestamps = [sample.timestamp_ms for sample in samples]
+        if any(later < earlier for earlier, later in zip(timestamps, timestamps[1:])):
+            return PreflightCheck("movement_trace", False, f"Trace timestamps are not monotonic: {trace_path}")
+        details.append(f"{trace_path} ({len(samples)} samples)")
+        loaded.append((trace_name, samples, start_ms))
+    if len(loaded) > 1:
+        try:
+            replayer.splice_traces(loaded)
+        except ValueError as exc:
+            return PreflightCheck("movement_trace", False, str(exc))
+    return PreflightCheck("movement_trace", True, "; ".join(details))
+
+
+def _check_network_trace(config: ExperimentConfig) -> PreflightCheck:
+    if not config.network_trace_path:
+        return PreflightCheck("network_trace", True, "No network trace configured.")
+    try:
+        trace_path = resolve_content_input(
+            config.network_trace_path,
+            "network_traces",
+            ".csv",
+        )
+        bandwidth_kbps = HeadlessTraceReplayer().load_network_trace(str(trace_path))
+    except (OSError, ValueError) as exc:
+        return PreflightCheck("network_trace", False, f"{type(exc).__name__}: {exc}")
+
+    if not bandwidth_kbps:
+        return PreflightCheck("network_trace", False, f"Network trace has no numeric samples: {trace_path}")
+    return PreflightCheck("network_trace", True, f"{trace_path} ({len(bandwidth_kbps)} samples)")
+
+
+def _check_abr_profile(config: ExperimentConfig) -> PreflightCheck:
+    if not config.abr_profile_path:
+        return PreflightCheck("abr_profile", True, "No ABR profile configured.")
+    try:
+        profile_path = resolve_abr_profile(config.abr_profile_path)
+        with profile_path.open("r", encoding="utf-8") as handle:
+            payload = json.load(handle)
+    except (OSError, ValueError) as exc:
+        return PreflightCheck("abr_profile", False, f"{type(exc).__name__}: {exc}")
+
+    try:
+        raw_bitrates = [int(value) for value in payload.get("bitrates_kbps", [])]
+        lods = {str(lod) for lod in payload.get("lods", [])}
+        min_bitrate_kbps = int(payload.get("min_bitrate_kbps", 300))
+        max_bitrate_kbps = int(payload.get("max_bitrate_kbps", 10000))
+    except (AttributeError, TypeError, ValueError) as exc:
+        return PreflightCheck("abr_profile", False, f"Malformed ABR profile: {type(exc).__name__}: {exc}")
+    if raw_bitrates != sorted(set(raw_bitrates)):
+        return PreflightCheck(
+            "abr_profile",
+            False,
+            "bitrates_kbps must be strictly ascending so lods stay aligned with their rungs.",
+        )
+    known_lods = set(get_args(LodId))
+    unknown = sorted(lods - known_lods)
+    if unknown:
+        return PreflightCheck("abr_profile", False, f"Unknown LOD ids in ladder: {', '.join(unknown)}")
+    if min_bitrate_kbps > max_bitrate_kbps:
+        return PreflightCheck("abr_profile", False, "min_bitrate_kbps exceeds max_bitrate_kbps.")
+
+    try:
+        profile = restrict_ladder(
+            AbrProfile.from_dict(payload),
+            min_profile=config.min_profile,
+            max_profile=config.max_profile,
+        )
+        build_client_abr_controller(profile, estimator=build_throughput_estimator(profile))
+    except (AttributeError, TypeError, ValueError) as exc:
+        return PreflightCheck("abr_profile", False, str(exc))
+    return PreflightCheck("abr_profile", True, f"{profile_path} ({profile.algorithm}, {len(raw_bitrates)} rungs)")
+
+
+def _check_scenario(config: ExperimentConfig) -> PreflightCheck:
+    if not config.scenario_path:
+        return PreflightCheck("scenario", True, "No scenario configured.")
+    try:
+        scenario_path = resolve_content_input(config.scenario_path, "scenarios", ".json")
+        scenario = load_scenario(scenario_path)
+    except (OSError, ValueError) as exc:
+        return PreflightCheck("scenario", False, f"{type(exc).__name__}: {exc}")
+    if not config.abr_profile_path and any(action.action == "pin_profile" for action in scenario.actions):
+        return PreflightCheck("scenario", False, "pin_profile actions require --abr-profile.")
+    if config.abr_profile_path:
+        try:
+            profile = load_abr_profile(resolve_abr_profile(config.abr_profile_path))
+        except (AttributeError, OSError, TypeError, ValueError):
+            # The abr_profile check reports an unloadable profile.
+            profile = None
+        if profile is not None:
+            try:
+                check_profile_pins(scenario, profile, config.min_profile, config.max_profile)
+            except ValueError as exc:
+                return PreflightCheck("scenario", False, str(exc))
+    return PreflightCheck("scenario", True, f"{scenario_path} ({len(scenario.actions)} actions)")
+
+
+def _check_tc(config: ExperimentConfig) -> PreflightCheck:
+    if not config.enable_tc:
+        return PreflightCheck("tc", True, "tc shaping disabled.")
+    if not config.tc_interface:
+        return PreflightCheck("tc", False, "--enable-tc requires --tc-interface.")
+    if shutil.which("tc") is None:
+        return PreflightCheck("tc", False, "tc binary not found in PATH.")
+    if not Path("/sys/class/net", config.tc_interface).exists():
+        return PreflightCheck("tc", False, f"Network interface not found: {config.tc_interface}")
+    return PreflightCheck("tc", True, f"tc available for {config.tc_interface}")
+
+
+def run_preflight(config: ExperimentConfig) -> list[PreflightCheck]:
+    """Run all preflight checks for one headless configuration."""
+    return [
+        _check_asset(config),
+        _check_movement_trace(config),
+        _check_network_trace(config),
+        _check_abr_profile(config),
+        _check_scenario(config),
+        _check_tc(config),
+    ]
+
+
+def preflight_report(checks: list[PreflightCheck]) -> dict:
+    """Summarize preflight checks as a JSON-serializable report."""
+    return {
+        "status": "ok" if all(check.ok for check in checks) else "failed",
+        "checks": [asdict(check) for check in checks],
+    }
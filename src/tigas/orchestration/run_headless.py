@@ -4,18 +4,51 @@ from __future__ import annotations
 
 import argparse
 import json
+import sys
 
+from tigas.instrumentation.udp_probe import parse_probe_target, probe_path
 from tigas.orchestration.ablation_runner import HeadlessAblationRunner
+from tigas.orchestration.preflight import preflight_report, run_preflight
+from tigas.orchestration.shutdown_report import ShutdownReport
+from tigas.shared.build_info import BuildInfoAction
+from tigas.shared.feature_flags import parse_flag_overrides
 from tigas.shared.types import ExperimentConfig
 
 
 def build_parser() -> argparse.ArgumentParser:
     parser = argparse.ArgumentParser(description="Run a runtime-only headless TIGAS render")
+    parser.add_argument(
+        "--version",
+        action=BuildInfoAction,
+        help="Print build info, git commit, features, and content inventory as JSON and exit",
+    )
     parser.add_argument("--ply-path", required=True, help="Path to .ply point cloud")
     parser.add_argument(
         "--movement-trace",
         default="",
-        help="Movement trace path or trace name in movement_traces (e.g. Circular)",
+        help=(
+            "Movement trace path or trace name in movement_traces (e.g. Circular). "
+            "Use name[@start_ms],... to switch traces mid-run (e.g. Circular,Linear@5000)"
+        ),
+    )
+    parser.add_argument(
+        "--trace-rate-hz",
+        type=float,
+        default=0.0,
+        help="Resample movement traces to this rate with slerp interpolation (0 keeps native timing)",
+    )
+    parser.add_argument(
+        "--max-pose-rate-hz",
+        type=float,
+        default=0.0,
+        help="Drop pose datagrams arriving faster than this rate (0 disables limiting)",
+    )
+    parser.add_argument(
+        "--pose-horizon-ms",
+        type=float,
+        default=0.0,
+        help="Advertise and enforce the useful pose rate for this prediction horizon "
+        "when --max-pose-rate-hz is unset (0 disables)",
     )
     parser.add_argument(
         "--network-trace",
@@ -27,6 +60,21 @@ def build_parser() -> argparse.ArgumentParser:
         default="",
         help="ABR profile JSON path or profile name in abr_profiles (throughput, bola, robustmpc)",
     )
+    parser.add_argument(
+        "--min-profile",
+        default="",
+        help="Lowest ladder rung the ABR may select, as pN (e.g. p1 excludes p0)",
+    )
+    parser.add_argument(
+        "--max-profile",
+        default="",
+        help="Highest ladder rung the ABR may select, as pN (e.g. p2)",
+    )
+    parser.add_argument(
+        "--scenario",
+        default="",
+        help="Scenario JSON/YAML path or name in scenarios of timed actions to apply during the run",
+    )
     parser.add_argument(
         "--enable-tc",
         action="store_true",
@@ -37,7 +85,11 @@ def build_parser() -> argparse.ArgumentParser:
         default="",
         help="Network interface to shape when --enable-tc is set (for example eth0 or lo)",
     )
-    parser.add_argument("--output-dir", default="outputs/headless", help="Reserved for compatibility")
+    parser.add_argument(
+        "--output-dir",
+        default="outputs/headless",
+        help="Directory for the shutdown report and black-box anomaly dumps",
+    )
     parser.add_argument("--num-frames", type=int, default=120, help="Number of frames to render")
     parser.add_argument("--fps", type=int, default=30, help="Frame rate used for timestamps")
     parser.add_argument("--width", type=int, default=960, help="Output frame width")
@@ -67,17 +119,62 @@ def build_parser() -> argparse.ArgumentParser:
         choices=["h264_nvenc", "av1_nvenc", "libx264", "videotoolbox_h264"],
         help="Experiment codec label",
     )
+    parser.add_argument(
+        "--seed",
+        type=int,
+        default=None,
+        help="Per-run seed for Python and NumPy randomness, recorded in the summary (random when omitted)",
+    )
+    parser.add_argument(
+        "--feature",
+        action="append",
+        default=[],
+        metavar="NAME=VALUE",
+        help="Override a feature flag from tigas.shared.feature_flags (repeatable)",
+    )
+    parser.add_argument(
+        "--latency-budget-ms",
+        type=float,
+        default=0.0,
+        help="Annotate every datagram with this response deadline and report budget hit rates (0 disables)",
+    )
+    parser.add_argument(
+        "--black-box-seconds",
+        type=float,
+        default=0.0,
+        help="Seconds of recent per-frame events to keep and dump on stalls or errors (0 disables)",
+    )
+    parser.add_argument(
+        "--deterministic",
+        action="store_true",
+        help="Keep wall-clock render time out of ABR inputs so identical inputs give identical decisions",
+    )
     parser.add_argument("--predictor", default="noop", help="Predictor label for run metadata")
     parser.add_argument(
         "--network-profile",
         default="wifi",
         help="Network profile label for run metadata",
     )
+    parser.add_argument(
+        "--probe-target",
+        default="",
+        help="host:port of a UDP probe responder; baseline RTT/loss is added to the summary as path_probe",
+    )
+    parser.add_argument(
+        "--check",
+        action="store_true",
+        help="Validate config, asset, ABR ladder, and trace files, print a report, and exit",
+    )
     return parser
 
 
 def main() -> None:
-    args = build_parser().parse_args()
+    parser = build_parser()
+    args = parser.parse_args()
+    try:
+        feature_flags = parse_flag_overrides(args.feature)
+    except ValueError as exc:
+        parser.error(str(exc))
     config = ExperimentConfig(
         trace_path=args.movement_trace,
         codec=args.codec,
@@ -85,8 +182,14 @@ def main() -> None:
         network_profile=args.network_profile,
         default_lod=args.default_lod,
         asset_path=args.ply_path,
+        trace_rate_hz=args.trace_rate_hz,
+        max_pose_rate_hz=args.max_pose_rate_hz,
+        pose_horizon_ms=args.pose_horizon_ms,
         network_trace_path=args.network_trace,
         abr_profile_path=args.abr_profile,
+        min_profile=args.min_profile,
+        max_profile=args.max_profile,
+        scenario_path=args.scenario or None,
         enable_tc=bool(args.enable_tc),
         tc_interface=args.tc_interface,
         output_dir=args.output_dir,
@@ -97,9 +200,33 @@ def main() -> None:
         max_points=args.max_points,
         renderer_backend=args.renderer_backend,
         quant_bits=args.quant_bits,
+        seed=args.seed,
+        deterministic=bool(args.deterministic),
+        black_box_seconds=args.black_box_seconds,
+        latency_budget_ms=args.latency_budget_ms,
+        feature_flags=feature_flags,
     )
-    summary = HeadlessAblationRunner().run_one(config)
-    print(json.dumps(summary, indent=2))
+    if args.check:
+        report = preflight_report(run_preflight(config))
+        print(json.dumps(report, indent=2))
+        sys.exit(0 if report["status"] == "ok" else 1)
+
+    shutdown = ShutdownReport()
+    try:
+        path_probe = None
+        if args.probe_target:
+            path_probe = probe_path(*parse_probe_target(args.probe_target)).to_dict()
+
+        summary = HeadlessAblationRunner().run_one(config)
+        shutdown.record_run(summary)
+        if path_probe is not None:
+            summary["path_probe"] = path_probe
+        print(json.dumps(summary, indent=2))
+    except BaseException as exc:
+        shutdown.record_error(exc)
+        raise
+    finally:
+        shutdown.write(config.output_dir)
 
 
 if __name__ == "__main__":
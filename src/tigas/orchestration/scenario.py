@@ -0,0 +1,165 @@
+"""Scripted scenarios of timed actions applied during a headless run.
+
+A scenario file lists actions keyed to session time, such as capping
+bandwidth at 30 s, dropping a marker at 60 s, or pinning the ladder to p1 at
+90 s. Times are relative to the first datagram. The runner fires each
+action at the first datagram at or after its time and records it in the
+summary timeline, so experiments no longer depend on external sleep-based
+scripts.
+
+Files are JSON, like profiles and traces:
+
+    {"name": "...", "actions": [{"at_s": 30, "action": "set_bandwidth_kbps", "kbps": 1500}, ...]}
+
+Files ending in `.yaml` or `.yml` hold the same structure in YAML and need
+PyYAML.
+"""
+
+from __future__ import annotations
+
+import json
+from dataclasses import dataclass, field
+from pathlib import Path
+
+from tigas.intelligence.abr_client import AbrProfile, restrict_ladder
+
+# Action name -> parameters it requires.
+SCENARIO_ACTIONS: dict[str, tuple[str, ...]] = {
+    "set_bandwidth_kbps": ("kbps",),
+    "clear_bandwidth": (),
+    "pin_profile": (),
+    "unpin_profile": (),
+    "marker": ("label",),
+}
+
+
+@dataclass(slots=True)
+class ScenarioAction:
+    """One timed action from a scenario file."""
+
+    at_ms: float
+    action: str
+    params: dict = field(default_factory=dict)
+
+    @classmethod
+    def from_dict(cls, payload: dict) -> "ScenarioAction":
+        action = str(payload.get("action", ""))
+        if action not in SCENARIO_ACTIONS:
+            raise ValueError(f"Unknown scenario action '{action}'. Expected one of {sorted(SCENARIO_ACTIONS)}.")
+        if "at_ms" in payload:
+            at_ms = float(payload["at_ms"])
+        elif "at_s" in payload:
+            at_ms = float(payload["at_s"]) * 1000.0
+        else:
+            raise ValueError(f"Scenario action '{action}' needs at_ms or at_s.")
+        if at_ms < 0.0:
+            raise ValueError(f"Scenario action '{action}' has a negative time.")
+
+        params = {key: value for key, value in payload.items() if key not in {"action", "at_ms", "at_s"}}
+        missing = [name for name in SCENARIO_ACTIONS[action] if name not in params]
+        if missing:
+            raise ValueError(f"Scenario action '{action}' is missing {', '.join(missing)}.")
+        if action == "set_bandwidth_kbps" and int(params["kbps"]) <= 0:
+            raise ValueError("set_bandwidth_kbps requires a positive kbps value.")
+        if action == "pin_profile" and not (params.get("min_profile") or params.get("max_profile")):
+            raise ValueError("pin_profile requires min_profile and/or max_profile.")
+        return cls(at_ms=at_ms, action=action, params=params)
+
+
+@dataclass(slots=True)
+class Scenario:
+    """Named, time-ordered list of scenario actions."""
+
+    name: str
+    actions: list[ScenarioAction]
+
+    @classmethod
+    def from_dict(cls, payload: dict) -> "Scenario":
+        actions = [ScenarioAction.from_dict(item) for item in payload.get("actions", [])]
+        actions.sort(key=lambda action: action.at_ms)
+        return cls(name=str(payload.get("name", "unnamed_scenario")), actions=actions)
+
+
+def load_scenario(scenario_path: Path) -> Scenario:
+    """Load and validate a scenario JSON or YAML file."""
+    scenario_path = Path(scenario_path)
+    with scenario_path.open("r", encoding="utf-8") as handle:
+        if scenario_path.suffix.lower() in {".yaml", ".yml"}:
+            try:
+                import yaml
+            except ImportError:
+                raise ValueError(f"Scenario {scenario_path} is YAML, which requires PyYAML.") from None
+            payload = yaml.safe_load(handle)
+        else:
+            payload = json.load(handle)
+    if not isinstance(payload, dict):
+        raise ValueError(f"Scenario {scenario_path} must hold a mapping with an 'actions' list.")
+    return Scenario.from_dict(payload)
+
+
+def pin_bounds(
+    action: ScenarioAction,
+    profile: AbrProfile,
+    min_profile: str = "",
+    max_profile: str = "",
+) -> tuple[str, str]:
+    """Return a pin_profile action's rung bounds intersected with the run's own bounds.
+
+    Pins and run bounds both name rungs of the full ladder; a pin can narrow
+    the run's `min_profile`..`max_profile` range but never widen it.
+    """
+    try:
+        pinned = restrict_ladder(
+            profile,
+            min_profile=str(action.params.get("min_profile", "")),
+            max_profile=str(action.params.get("max_profile", "")),
+        )
+    except ValueError as exc:
+        raise ValueError(f"Scenario pin_profile at {action.at_ms:g} ms: {exc}") from None
+    run = restrict_ladder(profile, min_profile=min_profile, max_profile=max_profile)
+    low_kbps = max(pinned.bitrates_kbps[0], run.bitrates_kbps[0])
+    high_kbps = min(pinned.bitrates_kbps[-1], run.bitrates_kbps[-1])
+    if low_kbps > high_kbps:
+        raise ValueError(
+            f"Scenario pin_profile at {action.at_ms:g} ms does not overlap the run's profile bounds "
+            f"({min_profile or 'p0'}..{max_profile or 'top'})."
+        )
+    return f"p{profile.bitrates_kbps.index(low_kbps)}", f"p{profile.bitrates_kbps.index(high_kbps)}"
+
+
+def check_profile_pins(scenario: Scenario, profile: AbrProfile, min_profile: str = "", max_profile: str = "") -> None:
+    """Raise ValueError if a pin_profile action names missing rungs or falls outside the run's bounds.
+
+    Runs before playback so a bad pin fails the run up front instead of when
+    the action fires mid-session.
+    """
+    for action in scenario.actions:
+        if action.action == "pin_profile":
+            pin_bounds(action, profile, min_profile, max_profile)
+
+
+class ScenarioPlayer:
+    """Release scenario actions as session time passes them."""
+
+    def __init__(self, scenario: Scenario, start_ms: float = 0.0) -> None:
+        self.scenario = scenario
+        self.start_ms = float(start_ms)
+        self._next = 0
+        self.fired: list[dict] = []
+
+    def due(self, timestamp_ms: float) -> list[ScenarioAction]:
+        """Return actions whose time has been reached, each exactly once."""
+        elapsed_ms = timestamp_ms - self.start_ms
+        actions: list[ScenarioAction] = []
+        while self._next < len(self.scenario.actions) and self.scenario.actions[self._next].at_ms <= elapsed_ms:
+            action = self.scenario.actions[self._next]
+            actions.append(action)
+            self.fired.append(
+                {"at_ms": action.at_ms, "timestamp_ms": timestamp_ms, "action": action.action, **action.params}
+            )
+            self._next += 1
+        return actions
+
+    @property
+    def pending_count(self) -> int:
+        return len(self.scenario.actions) - self._next
@@ -0,0 +1,76 @@
+"""Structured shutdown report for headless and evaluation processes.
+
+Every process writes one concise, machine-readable report on exit, whether it
+finished normally or failed: uptime, runs served, bytes by representation,
+errors by class, dropped pose datagrams, and trace cache counters. The report
+lands in the output directory as `shutdown_report.json` and on stderr, so a
+run can be triaged without opening its full summary. Stdout stays reserved for
+the summary itself, which callers parse as one JSON document.
+"""
+
+from __future__ import annotations
+
+import json
+import sys
+import time
+from datetime import datetime, timezone
+from pathlib import Path
+
+from tigas import __version__
+
+SHUTDOWN_REPORT_NAME = "shutdown_report.json"
+
+
+class ShutdownReport:
+    """Aggregate per-run summaries and errors into one exit report."""
+
+    def __init__(self) -> None:
+        self.started_at_utc = datetime.now(timezone.utc)
+        self._started = time.monotonic()
+        self.sessions_served = 0
+        self.frames_rendered = 0
+        self.bytes_by_lod: dict[str, int] = {}
+        self.errors_by_class: dict[str, int] = {}
+        self.dropped_datagrams = 0
+        self.trace_cache: dict = {}
+
+    def record_run(self, summary: dict) -> None:
+        """Fold one runtime summary into the totals."""
+        self.sessions_served += 1
+        self.frames_rendered += int(summary.get("frames_rendered", 0))
+        for lod, size in summary.get("bytes_by_lod", {}).items():
+            self.bytes_by_lod[lod] = self.bytes_by_lod.get(lod, 0) + int(size)
+        self.dropped_datagrams += int(summary.get("pose_datagrams_dropped", 0))
+        # Cache counters are cumulative for the runner, so the latest run has the totals.
+        self.trace_cache = dict(summary.get("trace_cache", self.trace_cache))
+
+    def record_error(self, error: BaseException) -> None:
+        name = type(error).__name__
+        self.errors_by_class[name] = self.errors_by_class.get(name, 0) + 1
+
+    def to_dict(self) -> dict:
+        return {
+            "status": "error" if self.errors_by_class else "ok",
+            "started_at_utc": self.started_at_utc.isoformat(),
+            "uptime_s": time.monotonic() - self._started,
+            "sessions_served": self.sessions_served,
+            "frames_rendered": self.frames_rendered,
+            "bytes_total": sum(self.bytes_by_lod.values()),
+            "bytes_by_lod": dict(sorted(self.bytes_by_lod.items())),
+            "errors_by_class": dict(sorted(self.errors_by_class.items())),
+            "dropped_datagrams": self.dropped_datagrams,
+            "trace_cache": self.trace_cache,
+            "version": __version__,
+        }
+
+    def write(self, output_dir: str | Path) -> dict:
+        """Write the report under output_dir, print it on stderr, and return it."""
+        report = self.to_dict()
+        output_path = Path(output_dir)
+        output_path.mkdir(parents=True, exist_ok=True)
+        report_path = output_path / SHUTDOWN_REPORT_NAME
+        with report_path.open("w", encoding="utf-8") as handle:
+            json.dump(report, handle, indent=2)
+        report["report_path"] = str(report_path)
+        print(json.dumps({"shutdown_report": report}), file=sys.stderr, flush=True)
+        return report
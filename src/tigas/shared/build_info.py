@@ -0,0 +1,100 @@
+"""Build and environment inventory for run provenance.
+
+Collects package version, git commit, optional feature availability, and the
+bundled trace/profile inventory so orchestration can record exactly which code
+and content produced a run.
+"""
+
+from __future__ import annotations
+
+import argparse
+import importlib.util
+import json
+import os
+import platform
+import shutil
+import subprocess
+import sys
+from pathlib import Path
+
+from tigas import __version__
+
+PROJECT_ROOT = Path(__file__).resolve().parents[3]
+
+_OPTIONAL_MODULES = ("numpy", "PIL", "torch", "gsplat")
+_OPTIONAL_BINARIES = ("ffmpeg", "tc", "git")
+_CONTENT_FOLDERS = {
+    "movement_traces": ".json",
+    "network_traces": ".csv",
+    "abr_profiles": ".json",
+    "scenarios": ".json",
+}
+
+
+def _git(*args: str) -> str | None:
+    try:
+        completed = subprocess.run(
+            ["git", *args],
+            cwd=PROJECT_ROOT,
+            capture_output=True,
+            text=True,
+            timeout=5,
+            check=True,
+        )
+    except (OSError, subprocess.SubprocessError):
+        return None
+    return completed.stdout.strip()
+
+
+def git_revision() -> dict:
+    """Return the commit and dirty flag of the source checkout, if available."""
+    commit = os.environ.get("TIGAS_GIT_COMMIT") or _git("rev-parse", "HEAD")
+    status = _git("status", "--porcelain", "--untracked-files=no")
+    return {
+        "commit": commit or None,
+        "dirty": bool(status) if status is not None else None,
+    }
+
+
+def feature_inventory() -> dict:
+    """Report which optional Python modules and host binaries are available."""
+    return {
+        "modules": {name: importlib.util.find_spec(name) is not None for name in _OPTIONAL_MODULES},
+        "binaries": {name: shutil.which(name) is not None for name in _OPTIONAL_BINARIES},
+    }
+
+
+def content_inventory(project_root: Path = PROJECT_ROOT) -> dict:
+    """List bundled trace and profile names by content folder."""
+    inventory: dict[str, list[str]] = {}
+    for folder, suffix in _CONTENT_FOLDERS.items():
+        folder_path = project_root / folder
+        if not folder_path.is_dir():
+            inventory[folder] = []
+            continue
+        inventory[folder] = sorted(path.stem for path in folder_path.glob(f"*{suffix}"))
+    return inventory
+
+
+def build_info() -> dict:
+    """Return the full provenance record embedded in run summaries."""
+    return {
+        "package": "tigas",
+        "version": __version__,
+        "git": git_revision(),
+        "python": sys.version.split()[0],
+        "platform": platform.platform(),
+        "features": feature_inventory(),
+        "content": content_inventory(),
+    }
+
+
+class BuildInfoAction(argparse.Action):
+    """argparse action that prints build info as JSON and exits."""
+
+    def __init__(self, option_strings, dest=argparse.SUPPRESS, default=argparse.SUPPRESS, help=None) -> None:
+        super().__init__(option_strings=option_strings, dest=dest, default=default, nargs=0, help=help)
+
+    def __call__(self, parser, namespace, values, option_string=None) -> None:
+        print(json.dumps(build_info(), indent=2))
+        parser.exit()
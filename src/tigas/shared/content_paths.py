@@ -0,0 +1,35 @@
+"""Resolution of bundled content names to files on disk.
+
+Traces, network traces, and scenarios can be passed as paths or as names of
+files in the repository's content folders. This module stays free of renderer
+and numpy imports so CLI tools that only read content can use it.
+"""
+
+from __future__ import annotations
+
+from pathlib import Path
+
+PROJECT_ROOT = Path(__file__).resolve().parents[3]
+
+
+def resolve_content_input(content_arg: str | None, folder: str, suffix: str) -> Path | None:
+    """Resolve a content argument by path, or by name in `folder` with `suffix`.
+
+    Returns None when no argument is given and raises FileNotFoundError when
+    neither the path nor the named file exists.
+    """
+    if not content_arg:
+        return None
+
+    candidate = Path(content_arg)
+    if candidate.exists():
+        return candidate
+
+    folder_path = PROJECT_ROOT / folder
+    by_name = folder_path / f"{content_arg}{suffix}"
+    if by_name.exists():
+        return by_name
+
+    raise FileNotFoundError(
+        f"Could not resolve trace '{content_arg}'. Checked path and {folder_path}/{content_arg}{suffix}."
+    )
@@ -0,0 +1,87 @@
+"""Registry of experimental feature flags.
+
+Each flag has a typed default and a description. A run's effective values are
+the defaults plus that run's overrides, and they are written into every run
+summary. The recorded data therefore says which experimental condition
+produced it, even when the command line is gone. A cohort is a set of runs
+sharing the same overrides (`--feature NAME=VALUE`). `tigas-report` lists the
+flag values of each compared group.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+
+
+@dataclass(frozen=True, slots=True)
+class FeatureFlag:
+    """One registered flag and its default value."""
+
+    name: str
+    default: bool | int | float | str
+    description: str
+
+
+FEATURE_FLAGS: dict[str, FeatureFlag] = {
+    flag.name: flag
+    for flag in (
+        FeatureFlag(
+            "abr_freeze_window",
+            True,
+            "Honor the ABR profile's freeze_window_ms after startup and seeks.",
+        ),
+        FeatureFlag(
+            "abr_degraded_mode",
+            True,
+            "Allow the ABR profile's degraded radio mode below p0.",
+        ),
+        FeatureFlag(
+            "abr_server_guardrail",
+            True,
+            "Let the server overload guardrail override client ABR decisions.",
+        ),
+    )
+}
+
+_TRUE = frozenset({"1", "true", "on", "yes"})
+_FALSE = frozenset({"0", "false", "off", "no"})
+
+
+def _coerce(flag: FeatureFlag, value: object) -> bool | int | float | str:
+    if isinstance(flag.default, bool):
+        if isinstance(value, bool):
+            return value
+        text = str(value).strip().lower()
+        if text in _TRUE:
+            return True
+        if text in _FALSE:
+            return False
+        raise ValueError(f"Feature flag '{flag.name}' expects a boolean, got '{value}'.")
+    try:
+        return type(flag.default)(value)
+    except (TypeError, ValueError):
+        raise ValueError(
+            f"Feature flag '{flag.name}' expects {type(flag.default).__name__}, got '{value}'."
+        ) from None
+
+
+def parse_flag_overrides(specs: list[str]) -> dict[str, object]:
+    """Parse `NAME=VALUE` command-line overrides into typed values."""
+    overrides: dict[str, object] = {}
+    for spec in specs:
+        name, separator, value = spec.partition("=")
+        if not separator:
+            raise ValueError(f"Feature override must be NAME=VALUE, got '{spec}'.")
+        overrides.update(resolve_feature_flags({name.strip(): value.strip()}, include_defaults=False))
+    return overrides
+
+
+def resolve_feature_flags(overrides: dict | None = None, include_defaults: bool = True) -> dict[str, object]:
+    """Return effective flag values, rejecting unknown names and mistyped values."""
+    unknown = sorted(set(overrides or {}) - set(FEATURE_FLAGS))
+    if unknown:
+        raise ValueError(f"Unknown feature flag(s) {unknown}. Expected one of {sorted(FEATURE_FLAGS)}.")
+    effective = {name: flag.default for name, flag in FEATURE_FLAGS.items()} if include_defaults else {}
+    for name, value in (overrides or {}).items():
+        effective[name] = _coerce(FEATURE_FLAGS[name], value)
+    return dict(sorted(effective.items()))
@@ -25,6 +25,10 @@ class UplinkDatagram:
     camera_matrix_4x4: list[float]
     requested_lod: LodId
     target_bitrate_kbps: int
+    # Optional deadline for the response frame; unannotated datagrams carry None.
+    latency_budget_ms: Optional[float] = None
+    # Optional client playback position, used to place server events on the media timeline.
+    media_time_ms: Optional[float] = None
 
 
 @dataclass(slots=True)
@@ -101,8 +105,15 @@ class ExperimentConfig:
     network_profile: str
     default_lod: LodId
     asset_path: Optional[str] = None
+    trace_rate_hz: float = 0.0
+    max_pose_rate_hz: float = 0.0
+    # Derives the advertised pose rate when max_pose_rate_hz is unset.
+    pose_horizon_ms: float = 0.0
     network_trace_path: Optional[str] = None
     abr_profile_path: Optional[str] = None
+    min_profile: str = ""
+    max_profile: str = ""
+    scenario_path: Optional[str] = None
     enable_tc: bool = False
     tc_interface: Optional[str] = None
     output_dir: str = "outputs/headless"
@@ -113,3 +124,9 @@ class ExperimentConfig:
     max_points: int = 120000
     renderer_backend: RendererBackendId = "cpu"
     quant_bits: int = 8
+    seed: Optional[int] = None
+    deterministic: bool = False
+    black_box_seconds: float = 0.0
+    latency_budget_ms: float = 0.0
+    # Overrides for tigas.shared.feature_flags; unset flags keep their registry defaults.
+    feature_flags: Dict[str, object] = field(default_factory=dict)
@@ -1,7 +1,15 @@
 """Ablation runner scaffold smoke tests."""
 
+import json
+import random
+import time
+from pathlib import Path
+
+import pytest
+
+from tigas.evaluation.network_profiles import synthetic_network, write_network_trace
 from tigas.orchestration.ablation_runner import HeadlessAblationRunner
-from tigas.shared.types import ExperimentConfig
+from tigas.shared.types import ExperimentConfig, RawFrame
 
 
 class _StubRunner(HeadlessAblationRunner):
@@ -39,3 +47,516 @@ def test_run_matrix_aggregates_results() -> None:
     assert len(results) == 2
     assert results[0]["codec"] == "libx264"
     assert results[1]["predictor"] == "kalman"
+
+
+class _StubRenderer:
+    backend_name = "stub"
+    loaded_point_count = 1
+    scene_radius = 1.0
+    scene_center = (0.0, 0.0, 0.0)
+
+    def initialize(self) -> None:
+        pass
+
+    def render(self, request):
+        size = 4000 if request.lod_id == "full" else 1500
+        return RawFrame(
+            frame_id=0, width=4, height=4, pixel_format="rgb24", is_keyframe_hint=False, data=b"\x00" * size
+        )
+
+    def shutdown(self) -> None:
+        pass
+
+
+class _StubRendererRunner(HeadlessAblationRunner):
+    def _build_renderer(self, config: ExperimentConfig, point_cloud_path: Path):
+        return _StubRenderer()
+
+    def _resolve_point_cloud_path(self, config: ExperimentConfig) -> Path:
+        return Path("stub.ply")
+
+
+class _FullHdRenderer(_StubRenderer):
+    def render(self, request):
+        return RawFrame(
+            frame_id=0,
+            width=1920,
+            height=1080,
+            pixel_format="rgb24",
+            is_keyframe_hint=False,
+            data=b"\x00" * (1920 * 1080 * 3),
+        )
+
+
+class _FullHdRendererRunner(_StubRendererRunner):
+    def _build_renderer(self, config: ExperimentConfig, point_cloud_path: Path):
+        return _FullHdRenderer()
+
+
+def test_full_hd_frames_without_network_trace_keep_the_estimate_on_the_ladder(tmp_path) -> None:
+    config = ExperimentConfig(
+        trace_path="",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="adaptive",
+        abr_profile_path="throughput",
+        num_frames=10,
+        seed=423,
+        deterministic=True,
+        output_dir=str(tmp_path),
+    )
+    summary = _FullHdRendererRunner().run_one(config)
+
+    # Every raw 1080p sample reads as ~1.5 Gbit/s and is clamped to the profile's 7000 kbps ceiling.
+    # Those clamps are not rejections of a real measurement, so none are counted.
+    assert summary["abr_rejected_throughput_samples"] == 0
+    assert summary["abr_throughput_kbps_mean"] == 7000.0
+    assert [switch["to_kbps"] for switch in summary["abr_switches"]] == [6000]
+
+    config.network_trace_path = "lte_steps"
+    summary = _FullHdRendererRunner().run_one(config)
+
+    assert summary["abr_rejected_throughput_samples"] == 10
+    assert summary["abr_throughput_kbps_mean"] < 7000.0
+
+
+def test_pose_horizon_advertises_and_enforces_the_useful_rate(tmp_path) -> None:
+    summary = _StubRendererRunner().run_one(
+        ExperimentConfig(
+            trace_path="",
+            codec="libx264",
+            predictor="noop",
+            network_profile="lte",
+            default_lod="full",
+            pose_horizon_ms=50.0,
+            num_frames=60,
+            fps=120,
+            output_dir=str(tmp_path),
+        )
+    )
+
+    # Four poses per 50 ms horizon is 80 Hz, so every other 120 Hz pose is dropped.
+    assert summary["pose_rate_advertisement"] == {"max_pose_rate_hz": 80.0}
+    assert summary["pose_rate_limit_hz"] == 80.0
+    assert summary["pose_datagrams_dropped"] == 30
+    assert summary["frames_rendered"] == 30
+
+
+class _JitteredRenderer(_StubRenderer):
+    """Samples frame sizes from the seeded global generator; render time jitters like a real GPU."""
+
+    def render(self, request):
+        if random.SystemRandom().random() < 0.5:
+            time.sleep(0.04)
+        size = random.randint(1000, 4000)
+        return RawFrame(
+            frame_id=0, width=4, height=4, pixel_format="rgb24", is_keyframe_hint=False, data=b"\x00" * size
+        )
+
+
+class _JitteredRendererRunner(_StubRendererRunner):
+    def _build_renderer(self, config: ExperimentConfig, point_cloud_path: Path):
+        return _JitteredRenderer()
+
+
+def test_deterministic_runs_record_seed_and_repeat_decisions() -> None:
+    config = ExperimentConfig(
+        trace_path="",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="adaptive",
+        network_trace_path="lte_steps",
+        abr_profile_path="throughput",
+        num_frames=60,
+        seed=422,
+        deterministic=True,
+    )
+
+    first = _StubRendererRunner().run_one(config)
+    second = _StubRendererRunner().run_one(config)
+
+    assert first["seed"] == second["seed"] == 422
+    assert first["deterministic"] is True
+    assert first["abr_switches"] == second["abr_switches"]
+    assert first["abr_lod_distribution"] == second["abr_lod_distribution"]
+    assert first["bytes_by_lod"] == second["bytes_by_lod"]
+    assert first["bandwidth_usage"]["by_transport"]["frame"] == sum(first["bytes_by_lod"].values())
+    assert first["bandwidth_usage"]["by_content"] == {"stub": first["bandwidth_usage"]["total_bytes"]}
+
+
+def test_deterministic_mode_repeats_runs_with_random_renderers() -> None:
+    config = ExperimentConfig(
+        trace_path="",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="adaptive",
+        abr_profile_path="throughput",
+        num_frames=30,
+        seed=422,
+        deterministic=True,
+    )
+
+    first = _JitteredRendererRunner().run_one(config)
+    second = _JitteredRendererRunner().run_one(config)
+
+    assert first["abr_switches"] == second["abr_switches"]
+    assert first["bytes_by_lod"] == second["bytes_by_lod"]
+
+    config.deterministic = False
+    first = _JitteredRendererRunner().run_one(config)
+    second = _JitteredRendererRunner().run_one(config)
+
+    # Measured render time now reaches the server guardrail, so the jitter changes decisions.
+    assert first["abr_switches"] != second["abr_switches"]
+
+
+def test_quality_floor_overrides_network_cap() -> None:
+    config = ExperimentConfig(
+        trace_path="",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="adaptive",
+        network_trace_path="lte_steps",
+        abr_profile_path="throughput",
+        min_profile="p3",
+        num_frames=60,
+        seed=446,
+        deterministic=True,
+    )
+
+    summary = _StubRendererRunner().run_one(config)
+
+    assert summary["abr_quality_bounds"]["min_kbps"] == 4000
+    assert summary["abr_bound_clamps"] > 0
+    assert min(row["bitrate_kbps_mean"] for row in summary["quality_timeline"]) >= 4000
+    assert set(summary["abr_lod_distribution"]) == {"full"}
+
+
+def test_scenario_caps_bandwidth_and_pins_profile(tmp_path) -> None:
+    scenario_path = tmp_path / "scenario.json"
+    scenario_path.write_text(
+        json.dumps(
+            {
+                "name": "pin",
+                "actions": [
+                    {"at_ms": 0, "action": "pin_profile", "min_profile": "p4"},
+                    {"at_ms": 500, "action": "set_bandwidth_kbps", "kbps": 900},
+                    {"at_ms": 1000, "action": "unpin_profile"},
+                    {"at_s": 60, "action": "marker", "label": "after end"},
+                ],
+            }
+        ),
+        encoding="utf-8",
+    )
+    config = ExperimentConfig(
+        trace_path="",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="adaptive",
+        abr_profile_path="throughput",
+        scenario_path=str(scenario_path),
+        num_frames=60,
+        seed=452,
+        deterministic=True,
+    )
+
+    summary = _StubRendererRunner().run_one(config)
+    timeline = summary["quality_timeline"]
+
+    assert summary["scenario"] == "pin"
+    assert [event["action"] for event in summary["scenario_events"]] == [
+        "pin_profile",
+        "set_bandwidth_kbps",
+        "unpin_profile",
+    ]
+    assert summary["scenario_pending_actions"] == 1
+    assert summary["abr_quality_bounds"] is None
+    # Pinned to the 6000 kbps top rung while the cap is active: the pin wins.
+    assert timeline[0]["bitrate_kbps_mean"] == 6000
+    assert max(row["bitrate_kbps_mean"] for row in timeline[1:]) <= 900
+
+
+def test_scenario_pins_stay_within_the_run_profile_bounds(tmp_path) -> None:
+    scenario_path = tmp_path / "scenario.json"
+    scenario_path.write_text(
+        json.dumps({"name": "pin", "actions": [{"at_ms": 0, "action": "pin_profile", "min_profile": "p4"}]}),
+        encoding="utf-8",
+    )
+    config = ExperimentConfig(
+        trace_path="",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="adaptive",
+        abr_profile_path="throughput",
+        scenario_path=str(scenario_path),
+        max_profile="p2",
+        num_frames=30,
+        seed=452,
+        deterministic=True,
+    )
+
+    with pytest.raises(ValueError, match="does not overlap"):
+        _StubRendererRunner().run_one(config)
+
+    scenario_path.write_text(
+        json.dumps({"name": "pin", "actions": [{"at_ms": 0, "action": "pin_profile", "min_profile": "p1"}]}),
+        encoding="utf-8",
+    )
+    summary = _StubRendererRunner().run_one(config)
+
+    assert summary["abr_quality_bounds"]["max_kbps"] == 2500
+    # The pin raises the floor to p1 but cannot lift the run's p2 ceiling.
+    assert all(1500 <= row["bitrate_kbps_mean"] <= 2500 for row in summary["quality_timeline"])
+
+
+class _CountingRenderer(_StubRenderer):
+    def __init__(self) -> None:
+        self.calls = 0
+
+    def render(self, request):
+        self.calls += 1
+        return super().render(request)
+
+
+class _CountingRendererRunner(_StubRendererRunner):
+    def __init__(self) -> None:
+        super().__init__()
+        self.renderer = _CountingRenderer()
+
+    def _build_renderer(self, config: ExperimentConfig, point_cloud_path: Path):
+        return self.renderer
+
+
+def test_scenario_pin_outside_the_ladder_fails_before_rendering(tmp_path) -> None:
+    scenario_path = tmp_path / "scenario.json"
+    scenario_path.write_text(
+        json.dumps({"name": "bad_pin", "actions": [{"at_s": 1, "action": "pin_profile", "min_profile": "p9"}]}),
+        encoding="utf-8",
+    )
+    runner = _CountingRendererRunner()
+    config = ExperimentConfig(
+        trace_path="",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="adaptive",
+        abr_profile_path="throughput",
+        scenario_path=str(scenario_path),
+        num_frames=60,
+        black_box_seconds=1.0,
+        output_dir=str(tmp_path),
+    )
+
+    with pytest.raises(ValueError, match="p9"):
+        runner.run_one(config)
+    assert runner.renderer.calls == 0
+    assert not (tmp_path / "black_box").exists()
+
+
+def test_pinned_bounds_take_the_lod_of_the_clamped_rung(tmp_path) -> None:
+    scenario_path = tmp_path / "scenario.json"
+    scenario_path.write_text(
+        json.dumps(
+            {
+                "name": "pin_middle",
+                "actions": [
+                    {"at_ms": 0, "action": "pin_profile", "min_profile": "p2", "max_profile": "p3"},
+                    {"at_ms": 500, "action": "set_bandwidth_kbps", "kbps": 900},
+                ],
+            }
+        ),
+        encoding="utf-8",
+    )
+    config = ExperimentConfig(
+        trace_path="",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="adaptive",
+        abr_profile_path="throughput",
+        scenario_path=str(scenario_path),
+        num_frames=60,
+        seed=446,
+        deterministic=True,
+        output_dir=str(tmp_path),
+    )
+
+    summary = _StubRendererRunner().run_one(config)
+
+    # The 900 kbps cap pushes the client to p0 (quant_8bit); the p2 floor ships p2's sampled_50 instead.
+    assert summary["abr_lod_distribution"] == {"sampled_50": 60}
+    assert {row["bitrate_kbps_mean"] for row in summary["quality_timeline"]} == {2500.0}
+
+
+def _bundled_trace_config(tmp_path, **overrides) -> ExperimentConfig:
+    fields = dict(
+        trace_path="Circular",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="full",
+        num_frames=300,
+        seed=392,
+        deterministic=True,
+        output_dir=str(tmp_path),
+    )
+    fields.update(overrides)
+    return ExperimentConfig(**fields)
+
+
+def test_bundled_trace_cadence_is_not_reported_as_gap_stalls(tmp_path) -> None:
+    native = _StubRendererRunner().run_one(_bundled_trace_config(tmp_path))
+    resampled = _StubRendererRunner().run_one(_bundled_trace_config(tmp_path, trace_rate_hz=10))
+
+    # The ~14 Hz trace only pauses once in this window (1343 ms -> 1571 ms).
+    assert [(stall["cause"], stall["end_ms"]) for stall in native["stall_intervals"]] == [("gap", 1571.0)]
+    assert resampled["frames_rendered"] == 300
+    assert resampled["stall_intervals"] == []
+
+
+def _outage_config(tmp_path, **overrides) -> ExperimentConfig:
+    network_trace = write_network_trace(
+        tmp_path / "outage.csv",
+        synthetic_network("outage", samples=120, at=60, length=20),
+    )
+    fields = dict(
+        trace_path="",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="adaptive",
+        network_trace_path=str(network_trace),
+        abr_profile_path="throughput",
+        num_frames=120,
+        seed=458,
+        deterministic=True,
+        output_dir=str(tmp_path),
+    )
+    fields.update(overrides)
+    return ExperimentConfig(**fields)
+
+
+def test_black_box_dumps_window_on_stall_onset(tmp_path) -> None:
+    summary = _StubRendererRunner().run_one(_outage_config(tmp_path, black_box_seconds=1.0))
+
+    assert summary["stall_count"] == 1
+    assert len(summary["black_box_dumps"]) == 1
+    dump = summary["black_box_dumps"][0]
+    assert dump["trigger"] == "stall_buffer_empty"
+    events = json.loads(Path(dump["path"]).read_text(encoding="utf-8"))["events"]
+    assert events[-1]["stall_cause"] == "buffer_empty"
+    assert events[-1]["timestamp_ms"] - events[0]["timestamp_ms"] <= 1000.0
+    assert {"lod", "target_kbps", "buffer_level_ms", "frame_bytes"} <= set(events[-1])
+
+
+def test_black_box_disabled_writes_nothing(tmp_path) -> None:
+    summary = _StubRendererRunner().run_one(_outage_config(tmp_path))
+
+    assert summary["black_box_dumps"] == []
+    assert not (tmp_path / "black_box").exists()
+
+
+class _FailingRenderer(_StubRenderer):
+    def __init__(self) -> None:
+        self.calls = 0
+
+    def render(self, request):
+        self.calls += 1
+        if self.calls > 10:
+            raise RuntimeError("renderer lost")
+        return super().render(request)
+
+
+class _FailingRendererRunner(_StubRendererRunner):
+    def _build_renderer(self, config: ExperimentConfig, point_cloud_path: Path):
+        return _FailingRenderer()
+
+
+def test_black_box_dumps_window_on_error(tmp_path) -> None:
+    config = _outage_config(tmp_path, black_box_seconds=5.0, network_trace_path=None)
+    try:
+        _FailingRendererRunner().run_one(config)
+    except RuntimeError:
+        pass
+    else:
+        raise AssertionError("expected the renderer failure to propagate")
+
+    dumps = sorted((tmp_path / "black_box").glob("*.json"))
+    assert [path.name for path in dumps] == ["black_box_s458_000_error.json"]
+    payload = json.loads(dumps[0].read_text(encoding="utf-8"))
+    assert payload["detail"] == "RuntimeError: renderer lost"
+    assert len(payload["events"]) == 10
+
+
+def test_latency_budget_misses_during_outage(tmp_path) -> None:
+    summary = _StubRendererRunner().run_one(_outage_config(tmp_path, latency_budget_ms=20.0))
+    budget = summary["latency_budget"]
+
+    assert budget["annotated"] == 120
+    assert budget["missed"] == 20
+    assert budget["hit_rate"] == 100 / 120
+    assert budget["worst_overrun_ms"] > 0.0
+
+
+def test_events_carry_media_time_behind_stalls(tmp_path) -> None:
+    summary = _StubRendererRunner().run_one(_outage_config(tmp_path))
+    stall = summary["stall_intervals"][0]
+
+    assert summary["media_clock"]["source"] == "derived_from_stalls"
+    assert stall["media_time_ms"] == stall["start_ms"]
+    for switch in summary["abr_switches"]:
+        expected_lag_ms = stall["duration_ms"] if switch["timestamp_ms"] >= stall["end_ms"] else 0.0
+        assert abs(switch["timestamp_ms"] - switch["media_time_ms"] - expected_lag_ms) < 1e-6
+
+    # The quality timeline follows playback position: the stall lands where playback froze
+    # and the last window holds fewer frames because playback ends behind the session.
+    timeline = summary["quality_timeline"]
+    assert sum(row["frames"] for row in timeline) == 120
+    assert timeline[int(stall["media_time_ms"] // 1000)]["stall_ms"] == stall["duration_ms"]
+    assert sum(row["stall_ms"] for row in timeline) == stall["duration_ms"]
+    assert timeline[-1]["frames"] < timeline[0]["frames"]
+
+
+def test_degraded_mode_replaces_stall_during_outage(tmp_path) -> None:
+    profile_path = tmp_path / "radio.json"
+    profile_path.write_text(
+        json.dumps(
+            {
+                "name": "radio",
+                "bitrates_kbps": [800, 1500, 2500, 4000, 6000],
+                "lods": ["quant_8bit", "sampled_50", "sampled_50", "full", "full"],
+                "degraded_entry_kbps": 400,
+                "degraded_bitrate_kbps": 96,
+            }
+        ),
+        encoding="utf-8",
+    )
+    baseline = _StubRendererRunner().run_one(_outage_config(tmp_path))
+    summary = _StubRendererRunner().run_one(_outage_config(tmp_path, abr_profile_path=str(profile_path)))
+
+    assert baseline["stall_count"] == 1 and baseline["abr_degraded_intervals"] == []
+    assert summary["stall_count"] == 0
+    (interval,) = summary["abr_degraded_intervals"]
+    assert interval["start_ms"] == 2000.0 and interval["exit_kbps"] == 5000.0
+    assert [switch["degraded_mode"] for switch in summary["abr_switches"] if switch["to_kbps"] == 96] == [True]
+    assert summary["feature_flags"]["abr_degraded_mode"] is True
+    assert [switch["network_capped"] for switch in summary["abr_switches"]] == [False, True, False]
+
+    app_limited = _StubRendererRunner().run_one(
+        _outage_config(tmp_path, abr_profile_path=str(profile_path), network_trace_path=None, num_frames=30)
+    )
+    # Without a network trace nothing caps the rate; the drop to 96 kbps is degraded mode alone.
+    (switch,) = app_limited["abr_switches"]
+    assert switch["degraded_mode"] is True and switch["network_capped"] is False
+
+    disabled = _StubRendererRunner().run_one(
+        _outage_config(tmp_path, abr_profile_path=str(profile_path), feature_flags={"abr_degraded_mode": "off"})
+    )
+    assert disabled["feature_flags"]["abr_degraded_mode"] is False
+    assert disabled["abr_degraded_intervals"] == [] and disabled["stall_count"] == 1
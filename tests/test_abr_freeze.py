@@ -0,0 +1,38 @@
+"""ABR freeze window tests."""
+
+from tigas.evaluation.abr_sim import AbrSimConfig, AbrSimulator
+from tigas.intelligence.abr_client import AbrProfile
+from tigas.intelligence.abr_freeze import AbrFreezeWindow
+
+
+def test_freeze_window_covers_events_for_configured_duration() -> None:
+    freeze = AbrFreezeWindow(window_ms=500.0)
+    freeze.notify("startup", 0.0)
+    freeze.notify("seek", 2000.0)
+
+    assert freeze.active_event(100.0) == "startup"
+    assert freeze.active_event(500.0) is None
+    assert freeze.active_event(2499.0) == "seek"
+    assert AbrFreezeWindow(window_ms=0.0).active_event(0.0) is None
+
+
+def test_simulator_holds_startup_decision_during_freeze() -> None:
+    payload = {
+        "name": "frozen",
+        "algorithm": "throughput",
+        "bitrates_kbps": [800, 2500, 6000],
+        "lods": ["quant_8bit", "sampled_50", "full"],
+        "ewma_alpha": 1.0,
+        "safety_factor": 1.0,
+        "min_bitrate_kbps": 800,
+        "max_bitrate_kbps": 6000,
+    }
+    bandwidth = [1000] + [8000] * 40
+    config = AbrSimConfig(chunk_duration_ms=1000.0)
+
+    free = AbrSimulator(AbrProfile.from_dict(payload), config).run(bandwidth)
+    frozen = AbrSimulator(AbrProfile.from_dict({**payload, "freeze_window_ms": 2500.0}), config).run(bandwidth)
+
+    assert free[2].bitrate_kbps == 6000
+    assert frozen[2].bitrate_kbps == frozen[0].bitrate_kbps
+    assert frozen[-1].bitrate_kbps == 6000
@@ -0,0 +1,122 @@
+"""ABR integration checks over synthetic network profiles."""
+
+import json
+import statistics
+from pathlib import Path
+
+from tigas.evaluation.abr_sim import AbrSimConfig, AbrSimulator, summarize_timeline
+from tigas.evaluation.content_gen import SyntheticContentConfig, generate_synthetic_content
+from tigas.evaluation.network_profiles import (
+    SYNTHETIC_NETWORK_PROFILES,
+    step_down,
+    step_up,
+    synthetic_network,
+    write_network_trace,
+)
+from tigas.input_control.headless_replayer import HeadlessTraceReplayer
+from tigas.intelligence.abr_client import load_abr_profile, resolve_abr_profile
+from tigas.orchestration.ablation_runner import HeadlessAblationRunner
+from tigas.shared.types import ExperimentConfig
+
+_REPOSITORY_PROFILES = ("throughput", "bola", "robustmpc")
+# Profiles whose decisions follow the throughput estimate; BOLA is buffer-driven.
+_THROUGHPUT_DRIVEN = ("throughput", "robustmpc")
+
+
+def _simulate(profile_name: str, bandwidth_kbps: list[int]) -> list:
+    profile = load_abr_profile(resolve_abr_profile(profile_name))
+    return AbrSimulator(profile, AbrSimConfig()).run(bandwidth_kbps)
+
+
+def test_all_profiles_stay_on_ladder_for_every_synthetic_network() -> None:
+    for profile_name in _REPOSITORY_PROFILES:
+        ladder = set(load_abr_profile(resolve_abr_profile(profile_name)).bitrates_kbps)
+        for network_name in SYNTHETIC_NETWORK_PROFILES:
+            steps = _simulate(profile_name, synthetic_network(network_name))
+            assert {step.bitrate_kbps for step in steps} <= ladder, (profile_name, network_name)
+
+
+def test_all_profiles_sustain_constant_bandwidth_without_rebuffering() -> None:
+    for profile_name in _REPOSITORY_PROFILES:
+        steps = _simulate(profile_name, synthetic_network("constant", kbps=5000))
+        summary = summarize_timeline(load_abr_profile(resolve_abr_profile(profile_name)), steps)
+
+        assert summary["rebuffer_ms_total"] == 0.0, profile_name
+        assert summary["bitrate_kbps_mean"] <= 5000, profile_name
+    # The first chunk starts on the lowest rung; one switch reaches the sustainable rung and it holds.
+    steps = _simulate("throughput", synthetic_network("constant", kbps=5000))
+    assert summarize_timeline(load_abr_profile(resolve_abr_profile("throughput")), steps)["switch_count"] == 1
+    assert {step.bitrate_kbps for step in steps[1:]} == {4000}
+
+
+def test_throughput_driven_profiles_follow_step_changes() -> None:
+    settle = 8
+    for profile_name in _THROUGHPUT_DRIVEN:
+        down = _simulate(profile_name, step_down(high_kbps=8000, low_kbps=1000, samples=40, at=15))
+        up = _simulate(profile_name, step_up(low_kbps=1000, high_kbps=8000, samples=40, at=10))
+
+        assert down[14].bitrate_kbps >= 4000, profile_name
+        assert statistics.fmean(step.bitrate_kbps for step in down[15 + settle :]) <= 1000, profile_name
+        assert up[9].bitrate_kbps <= 1000, profile_name
+        assert statistics.fmean(step.bitrate_kbps for step in up[10 + settle :]) >= 4000, profile_name
+
+
+def test_outage_rebuffers_and_throughput_driven_profiles_recover() -> None:
+    bandwidth = synthetic_network("outage", kbps=5000, outage_kbps=50, samples=40, at=15, length=4)
+    for profile_name in _REPOSITORY_PROFILES:
+        steps = _simulate(profile_name, bandwidth)
+        assert sum(step.rebuffer_ms for step in steps[15:22]) > 0.0, profile_name
+        if profile_name in _THROUGHPUT_DRIVEN:
+            assert statistics.fmean(step.bitrate_kbps for step in steps[-10:]) >= 2500, profile_name
+
+
+def test_synthetic_network_writes_loadable_trace(tmp_path) -> None:
+    bandwidth = synthetic_network("oscillating", high_kbps=6000, low_kbps=2000, samples=8, period=4)
+    trace_path = write_network_trace(tmp_path / "oscillating.csv", bandwidth)
+
+    assert bandwidth == [6000, 6000, 2000, 2000, 6000, 6000, 2000, 2000]
+    assert HeadlessTraceReplayer().load_network_trace(str(trace_path)) == bandwidth
+
+
+def test_headless_runner_follows_step_down_on_generated_content(tmp_path) -> None:
+    manifest = generate_synthetic_content(
+        tmp_path / "content",
+        SyntheticContentConfig(num_points=64, num_frames=60, network_step_samples=2, seed=454),
+    )
+    # Drive decisions from the transport-reported rate (the trace) rather than raw frame sizes.
+    profile_path = Path(manifest["abr_profile"])
+    profile = json.loads(profile_path.read_text(encoding="utf-8"))
+    profile_path.write_text(json.dumps({**profile, "estimators": ["reported"]}), encoding="utf-8")
+    network_trace = write_network_trace(
+        tmp_path / "step_down.csv",
+        step_down(high_kbps=6000, low_kbps=800, samples=60, at=30),
+    )
+
+    summary = HeadlessAblationRunner().run_one(
+        ExperimentConfig(
+            trace_path=manifest["movement_trace"],
+            codec="libx264",
+            predictor="noop",
+            network_profile="step_down",
+            default_lod="adaptive",
+            asset_path=manifest["scene_ply"],
+            network_trace_path=str(network_trace),
+            abr_profile_path=str(profile_path),
+            width=40,
+            height=20,
+            num_frames=60,
+            seed=454,
+            deterministic=True,
+            output_dir=str(tmp_path),
+        )
+    )
+
+    (switch,) = summary["abr_switches"]
+    assert (switch["seq_id"], switch["from_kbps"], switch["to_kbps"]) == (30, 2500, 800)
+    assert switch["network_capped"] is True and switch["degraded_mode"] is False
+    assert switch["estimates_kbps"] == {"reported": 6000.0}
+    assert summary["stall_intervals"] == []
+    assert [(row["lod"], row["bitrate_kbps_mean"], row["stall_ms"]) for row in summary["quality_timeline"]] == [
+        ("sampled_50", 2500.0, 0.0),
+        ("quant_8bit", 800.0, 0.0),
+    ]
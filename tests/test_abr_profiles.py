@@ -2,10 +2,17 @@
 
 from pathlib import Path
 
+import pytest
+
 from tigas.intelligence.abr_client import (
+    AbrProfile,
+    EnsembleEstimator,
+    ThroughputEstimator,
     build_client_abr_controller,
+    build_throughput_estimator,
     load_abr_profile,
     resolve_abr_profile,
+    restrict_ladder,
 )
 
 
@@ -33,3 +40,164 @@ def test_load_and_build_controllers_for_all_profiles() -> None:
         )
         assert decision.target_bitrate_kbps > 0
         assert decision.requested_lod in {"full", "sampled_50", "quant_8bit", "adaptive"}
+
+
+def test_decisions_carry_explanations() -> None:
+    profile = load_abr_profile(resolve_abr_profile("throughput"))
+    controller = build_client_abr_controller(profile)
+    decision = controller.decide(
+        throughput_kbps=900.0,
+        decode_latency_ms=5.0,
+        buffer_level_ms=5000.0,
+    )
+
+    explanation = decision.explanation
+    assert decision.target_bitrate_kbps == 800
+    assert explanation["algorithm"] == "throughput"
+    assert explanation["profile"] == "throughput"
+    assert explanation["inputs"]["throughput_kbps"] == 900.0
+    assert explanation["limited_by"] == "throughput"
+    assert explanation["thresholds"]["safe_target_kbps"] == 810.0
+
+
+def test_robustmpc_reports_the_penalty_that_held_the_rung_down() -> None:
+    profile = load_abr_profile(resolve_abr_profile("robustmpc"))
+
+    unconstrained = build_client_abr_controller(profile).decide(
+        throughput_kbps=50000.0, decode_latency_ms=0.0, buffer_level_ms=10000.0
+    )
+    assert unconstrained.target_bitrate_kbps == 6000
+    assert unconstrained.explanation["limited_by"] == "none"
+
+    starved = build_client_abr_controller(profile).decide(
+        throughput_kbps=1000.0, decode_latency_ms=0.0, buffer_level_ms=0.0
+    )
+    assert starved.target_bitrate_kbps == 800
+    assert starved.explanation["limited_by"] == "rebuffer_risk"
+
+    # Plenty of throughput and buffer, but leaving the 800 kbps start costs more than it gains.
+    profile.robustmpc_switch_penalty = 2.0
+    sticky = build_client_abr_controller(profile).decide(
+        throughput_kbps=50000.0, decode_latency_ms=0.0, buffer_level_ms=10000.0
+    )
+    assert sticky.target_bitrate_kbps == 800
+    assert sticky.explanation["thresholds"]["projected_rebuffer_s"] == 0.0
+    assert sticky.explanation["limited_by"] == "switch_penalty"
+
+
+def test_startup_probe_serves_lowest_rung_then_jumps() -> None:
+    profile = load_abr_profile(resolve_abr_profile("throughput"))
+    profile.startup_probe_samples = 2
+    estimator = build_throughput_estimator(profile)
+    controller = build_client_abr_controller(profile, estimator=estimator)
+
+    first = controller.decide(throughput_kbps=8000.0, decode_latency_ms=0.0, buffer_level_ms=0.0)
+    assert first.target_bitrate_kbps == 800
+    assert first.explanation["limited_by"] == "startup_probe"
+
+    estimator.observe(delivered_bytes=1_000_000, elapsed_s=1.0)
+    assert estimator.probing
+    estimator.observe(delivered_bytes=1_000_000, elapsed_s=1.0)
+    assert not estimator.probing
+    assert estimator.current(fallback_kbps=1.0) == 8000.0
+
+    after = controller.decide(
+        throughput_kbps=estimator.current(fallback_kbps=1.0),
+        decode_latency_ms=0.0,
+        buffer_level_ms=0.0,
+    )
+    assert after.target_bitrate_kbps == 6000
+
+
+def test_throughput_estimator_rejects_loopback_samples() -> None:
+    estimator = ThroughputEstimator(ewma_alpha=1.0)
+
+    estimator.observe(delivered_bytes=250_000, elapsed_s=1.0)
+    assert estimator.observe(delivered_bytes=10_000_000, elapsed_s=0.0001) == pytest.approx(2000.0)
+    corrected = estimator.observe(delivered_bytes=10_000_000, elapsed_s=0.0001, reference_kbps=3500.0)
+    assert corrected == pytest.approx(3500.0)
+    assert estimator.rejected_samples == 2
+
+
+def test_ensemble_estimator_tracks_members_and_selects_authoritative() -> None:
+    base = load_abr_profile(resolve_abr_profile("throughput"))
+    profile = AbrProfile.from_dict(
+        {
+            "name": "ensemble",
+            "algorithm": "throughput",
+            "bitrates_kbps": base.bitrates_kbps,
+            "lods": base.lods,
+            "ewma_alpha": 1.0,
+            "estimators": ["ewma", "sliding_window", "reported"],
+            "sliding_window_size": 2,
+            "authoritative_estimator": "sliding_window",
+        }
+    )
+    estimator = build_throughput_estimator(profile)
+
+    assert isinstance(estimator, EnsembleEstimator)
+    estimator.observe(delivered_bytes=125_000, elapsed_s=1.0, reference_kbps=900.0)
+    estimator.observe(delivered_bytes=375_000, elapsed_s=1.0, reference_kbps=900.0)
+
+    snapshot = estimator.snapshot(fallback_kbps=1.0)
+    assert snapshot["ewma"] == pytest.approx(3000.0)
+    assert snapshot["sliding_window"] == pytest.approx(1500.0)
+    assert snapshot["reported"] == pytest.approx(900.0)
+    assert estimator.current(fallback_kbps=1.0) == pytest.approx(1500.0)
+
+    estimator.authoritative = ""
+    assert estimator.current(fallback_kbps=1.0) == pytest.approx(1800.0)
+
+
+def test_unknown_estimator_kind_is_rejected() -> None:
+    profile = AbrProfile.from_dict({"bitrates_kbps": [1000], "estimators": ["kalman"]})
+
+    with pytest.raises(ValueError):
+        build_throughput_estimator(profile)
+
+
+def test_restrict_ladder_limits_decisions_to_bounded_rungs() -> None:
+    base = load_abr_profile(resolve_abr_profile("throughput"))
+    profile = restrict_ladder(base, min_profile="p1", max_profile="p3")
+
+    assert profile.bitrates_kbps == [1500, 2500, 4000]
+    assert profile.lods == ["sampled_50", "sampled_50", "full"]
+    assert (profile.min_bitrate_kbps, profile.max_bitrate_kbps) == (1500, 4000)
+
+    controller = build_client_abr_controller(profile)
+    low = controller.decide(throughput_kbps=100.0, decode_latency_ms=0.0, buffer_level_ms=2000.0)
+    high = controller.decide(throughput_kbps=50000.0, decode_latency_ms=0.0, buffer_level_ms=2000.0)
+    assert low.target_bitrate_kbps == 1500
+    assert high.target_bitrate_kbps == 4000
+
+
+def test_restrict_ladder_rejects_invalid_bounds() -> None:
+    profile = load_abr_profile(resolve_abr_profile("throughput"))
+
+    assert restrict_ladder(profile) is profile
+    for min_profile, max_profile in (("p5", ""), ("p3", "p1"), ("top", "")):
+        with pytest.raises(ValueError):
+            restrict_ladder(profile, min_profile=min_profile, max_profile=max_profile)
+
+
+def test_bare_estimator_is_uncapped_but_profile_estimators_cap_by_default() -> None:
+    estimator = ThroughputEstimator(ewma_alpha=1.0)
+    assert estimator.observe(delivered_bytes=6_220_800, elapsed_s=1.0 / 30.0) == pytest.approx(1_492_992.0)
+    assert estimator.rejected_samples == 0
+
+    profile = load_abr_profile(resolve_abr_profile("throughput"))
+    assert profile.max_plausible_kbps == 0.0
+    capped = build_throughput_estimator(profile)
+    assert capped.max_plausible_kbps == 60_000.0
+    assert capped.observe(delivered_bytes=6_220_800, elapsed_s=1.0 / 30.0, reference_kbps=8000.0) == 8000.0
+    assert capped.rejected_samples == 1
+
+
+def test_profile_estimator_caps_samples_relative_to_the_top_rung() -> None:
+    profile = load_abr_profile(resolve_abr_profile("throughput"))
+    estimator = build_throughput_estimator(profile)
+
+    assert estimator.observe(delivered_bytes=6_220_800, elapsed_s=1.0 / 30.0) == 7000.0
+    assert estimator.observe(delivered_bytes=6_220_800, elapsed_s=1.0 / 30.0, reference_kbps=2500.0) < 7000.0
+    assert estimator.rejected_samples == 2
+    assert estimator.observe(delivered_bytes=2_500_000, elapsed_s=1.0) > 6000.0
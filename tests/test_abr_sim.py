@@ -0,0 +1,39 @@
+"""Offline ABR simulation tests."""
+
+from tigas.evaluation.abr_sim import AbrSimConfig, AbrSimulator, summarize_timeline
+from tigas.intelligence.abr_client import load_abr_profile, resolve_abr_profile
+
+
+def test_throughput_profile_follows_bandwidth_steps() -> None:
+    profile = load_abr_profile(resolve_abr_profile("throughput"))
+    bandwidth = [8000] * 10 + [1000] * 10
+
+    steps = AbrSimulator(profile, AbrSimConfig(chunk_duration_ms=1000.0)).run(bandwidth)
+
+    assert len(steps) == 20
+    assert steps[9].bitrate_kbps == 6000
+    assert steps[-1].bitrate_kbps == 800
+    summary = summarize_timeline(profile, steps)
+    assert summary["switch_count"] >= 1
+    assert summary["num_chunks"] == 20
+
+
+def test_simulation_accounts_rebuffering_when_bandwidth_collapses() -> None:
+    profile = load_abr_profile(resolve_abr_profile("throughput"))
+    config = AbrSimConfig(chunk_duration_ms=1000.0, initial_buffer_ms=0.0, max_chunks=3)
+
+    steps = AbrSimulator(profile, config).run([8000, 100, 100, 100])
+
+    assert len(steps) == 3
+    assert steps[1].rebuffer_ms > 0.0
+    assert summarize_timeline(profile, steps)["rebuffer_events"] >= 1
+
+
+def test_first_chunk_does_not_see_the_trace() -> None:
+    profile = load_abr_profile(resolve_abr_profile("throughput"))
+    config = AbrSimConfig(chunk_duration_ms=1000.0, max_chunks=1)
+
+    fast = AbrSimulator(profile, config).run([50000])
+    slow = AbrSimulator(profile, config).run([900])
+
+    assert fast[0].bitrate_kbps == slow[0].bitrate_kbps == min(profile.bitrates_kbps)
@@ -0,0 +1,28 @@
+"""Bandwidth accounting tests."""
+
+from tigas.instrumentation.bandwidth_accounting import TRANSPORT_DATAGRAM, TRANSPORT_FRAME, BandwidthAccounting
+
+
+def test_accounting_breaks_down_bytes_and_snapshots_cumulatively() -> None:
+    accounting = BandwidthAccounting(snapshot_interval_ms=1000.0)
+    for index in range(25):
+        timestamp_ms = 5000.0 + index * 100.0
+        accounting.record(timestamp_ms, 100, TRANSPORT_DATAGRAM, "pose", "scene")
+        accounting.record(timestamp_ms, 1000 if index < 10 else 400, TRANSPORT_FRAME, "full", "scene")
+
+    usage = accounting.finish()
+
+    assert usage["total_bytes"] == 25 * 100 + 10 * 1000 + 15 * 400
+    assert usage["by_transport"] == {"datagram": 2500, "frame": 16000}
+    assert usage["by_representation"] == {"full": 16000, "pose": 2500}
+    assert usage["by_content"] == {"scene": 18500}
+    assert [snapshot["elapsed_ms"] for snapshot in usage["snapshots"]] == [1000.0, 2000.0, 2400.0]
+    assert usage["snapshots"][0]["by_transport"] == {"datagram": 1000, "frame": 10000}
+    assert usage["snapshots"][-1]["total_bytes"] == usage["total_bytes"]
+
+
+def test_empty_accounting_has_no_snapshots() -> None:
+    usage = BandwidthAccounting().finish()
+
+    assert usage["total_bytes"] == 0
+    assert usage["snapshots"] == []
@@ -0,0 +1,22 @@
+"""Black-box session recorder tests."""
+
+import json
+from pathlib import Path
+
+from tigas.instrumentation.black_box import BlackBoxRecorder
+
+
+def test_black_box_keeps_only_the_recent_window(tmp_path) -> None:
+    recorder = BlackBoxRecorder(window_ms=100.0, output_dir=tmp_path, max_dumps=1)
+    for timestamp_ms in range(0, 300, 10):
+        recorder.record({"timestamp_ms": float(timestamp_ms)})
+
+    dump = recorder.dump("stall_gap", 290.0)
+
+    assert dump["event_count"] == 11
+    payload = json.loads(Path(dump["path"]).read_text(encoding="utf-8"))
+    assert payload["trigger"] == "stall_gap"
+    assert [event["timestamp_ms"] for event in payload["events"]][0] == 190.0
+    assert recorder.dump("stall_gap", 300.0) is None
+    assert recorder.suppressed_dumps == 1
+
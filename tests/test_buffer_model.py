@@ -0,0 +1,27 @@
+"""Virtual client buffer model tests."""
+
+import pytest
+
+from tigas.intelligence.buffer_model import VirtualBufferModel
+
+
+def test_buffer_model_credits_drains_and_counts_stalls() -> None:
+    buffer = VirtualBufferModel(initial_ms=1000.0, max_ms=3000.0)
+
+    assert buffer.drain(400.0) == 0.0
+    assert buffer.level_ms == pytest.approx(600.0)
+    assert buffer.credit(3000.0) == pytest.approx(600.0)
+    assert buffer.level_ms == pytest.approx(3000.0)
+
+    assert buffer.drain(3500.0) == pytest.approx(500.0)
+    assert buffer.empty
+    assert buffer.empty_count == 1
+    assert buffer.stall_ms_total == pytest.approx(500.0)
+    assert buffer.overflow_ms_total == pytest.approx(600.0)
+
+
+def test_buffer_model_clamps_initial_level_and_rejects_zero_capacity() -> None:
+    assert VirtualBufferModel(initial_ms=9000.0, max_ms=4000.0).level_ms == 4000.0
+
+    with pytest.raises(ValueError):
+        VirtualBufferModel(max_ms=0.0)
@@ -0,0 +1,48 @@
+"""Build info and provenance tests."""
+
+import argparse
+import contextlib
+import io
+import json
+
+import pytest
+
+from tigas import __version__
+from tigas.shared.build_info import BuildInfoAction, build_info, content_inventory
+
+
+def test_build_info_reports_version_and_content() -> None:
+    info = build_info()
+
+    assert info["version"] == __version__
+    assert set(info["git"]) == {"commit", "dirty"}
+    assert "Circular" in info["content"]["movement_traces"]
+    assert "lte_steps" in info["content"]["network_traces"]
+    assert {"throughput", "bola", "robustmpc"} <= set(info["content"]["abr_profiles"])
+    assert json.loads(json.dumps(info)) == info
+
+
+def test_content_inventory_handles_missing_folders(tmp_path) -> None:
+    (tmp_path / "abr_profiles").mkdir()
+    (tmp_path / "abr_profiles" / "custom.json").write_text("{}", encoding="utf-8")
+
+    inventory = content_inventory(tmp_path)
+
+    assert inventory == {
+        "movement_traces": [],
+        "network_traces": [],
+        "abr_profiles": ["custom"],
+        "scenarios": [],
+    }
+
+
+def test_version_flag_prints_json_and_exits() -> None:
+    parser = argparse.ArgumentParser()
+    parser.add_argument("--version", action=BuildInfoAction)
+    parser.add_argument("--required", required=True)
+
+    output = io.StringIO()
+    with contextlib.redirect_stdout(output), pytest.raises(SystemExit):
+        parser.parse_args(["--version"])
+
+    assert json.loads(output.getvalue())["version"] == __version__
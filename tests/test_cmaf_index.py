@@ -0,0 +1,91 @@
+"""CMAF chunk boundary indexer tests."""
+
+import struct
+
+import pytest
+
+from tigas.media.cmaf_index import CmafIndexCache, index_cmaf_bytes
+
+
+def _box(box_type: bytes, payload: bytes) -> bytes:
+    return struct.pack(">I4s", 8 + len(payload), box_type) + payload
+
+
+def _full_box(box_type: bytes, version: int, flags: int, payload: bytes) -> bytes:
+    return _box(box_type, struct.pack(">I", (version << 24) | flags) + payload)
+
+
+def _trun(ctos: list[int]) -> bytes:
+    samples = b"".join(struct.pack(">Ii", 1000, cto) for cto in ctos)
+    return _full_box(b"trun", 1, 0x000100 | 0x000800, struct.pack(">I", len(ctos)) + samples)
+
+
+def _chunk(sequence_number: int, base_decode_time: int, *truns: list[int]) -> bytes:
+    mfhd = _full_box(b"mfhd", 0, 0, struct.pack(">I", sequence_number))
+    tfhd = _full_box(b"tfhd", 0, 0, struct.pack(">I", 1))
+    tfdt = _full_box(b"tfdt", 1, 0, struct.pack(">Q", base_decode_time))
+    moof = _box(b"moof", mfhd + _box(b"traf", tfhd + tfdt + b"".join(_trun(ctos) for ctos in truns)))
+    return moof + _box(b"mdat", b"\x00" * 32)
+
+
+def test_index_cmaf_bytes_finds_chunks_and_times() -> None:
+    data = _box(b"styp", b"cmfc") + _chunk(1, 90000, [2000, 0]) + _chunk(2, 92000, [1000, 1000])
+
+    chunks = index_cmaf_bytes(data)
+
+    assert len(chunks) == 2
+    assert chunks[0].sequence_number == 1
+    assert chunks[0].earliest_presentation_time == 91000
+    assert chunks[0].duration == 2000
+    assert chunks[1].moof_offset == chunks[0].end_offset
+    assert chunks[1].earliest_presentation_time == 93000
+    assert chunks[1].end_offset == len(data)
+    assert chunks[1].to_dict(timescale=1000)["earliest_presentation_time_s"] == 93.0
+
+
+def test_index_cache_reuses_until_file_changes(tmp_path) -> None:
+    segment = tmp_path / "seg_1.m4s"
+    segment.write_bytes(_chunk(1, 0, [0]))
+    cache = CmafIndexCache()
+
+    first = cache.get(str(segment))
+    assert cache.get(str(segment)) is first
+    assert first.chunk_at_offset(0) is first.chunks[0]
+
+    segment.write_bytes(_chunk(1, 0, [0]) + _chunk(2, 1000, [0]))
+    assert len(cache.get(str(segment)).chunks) == 2
+
+
+def test_index_cmaf_bytes_continues_decode_time_across_truns() -> None:
+    (chunk,) = index_cmaf_bytes(_chunk(1, 5000, [500, 500], [-900, 0]))
+
+    assert chunk.earliest_presentation_time == 5500
+    assert chunk.duration == 4000
+
+
+def test_index_cmaf_bytes_rejects_truncated_box_bodies() -> None:
+    truncated_trun = _full_box(b"trun", 0, 0x000100, struct.pack(">I", 1000))
+    moof = _box(b"moof", _box(b"traf", _full_box(b"tfhd", 0, 0, struct.pack(">I", 1)) + truncated_trun))
+    with pytest.raises(ValueError, match="samples past its end"):
+        index_cmaf_bytes(moof + _box(b"mdat", b""))
+
+    for box in (_box(b"tfdt", b"\x01"), _full_box(b"tfhd", 0, 0x000008, struct.pack(">I", 1))):
+        with pytest.raises(ValueError, match="Truncated box body"):
+            index_cmaf_bytes(_box(b"moof", _box(b"traf", box)) + _box(b"mdat", b""))
+
+
+def test_index_cmaf_bytes_rejects_huge_sample_counts_without_per_sample_fields() -> None:
+    empty_trun = _full_box(b"trun", 0, 0, struct.pack(">I", 0xFFFFFFFF))
+    moof = _box(b"moof", _box(b"traf", _full_box(b"tfhd", 0, 0, struct.pack(">I", 1)) + empty_trun))
+    with pytest.raises(ValueError, match="limit"):
+        index_cmaf_bytes(moof + _box(b"mdat", b""))
+
+
+def test_index_cmaf_bytes_times_default_duration_truns_arithmetically() -> None:
+    tfhd = _full_box(b"tfhd", 0, 0x000008, struct.pack(">II", 1, 40))
+    tfdt = _full_box(b"tfdt", 1, 0, struct.pack(">Q", 1000))
+    trun = _full_box(b"trun", 0, 0, struct.pack(">I", 25))
+    (chunk,) = index_cmaf_bytes(_box(b"moof", _box(b"traf", tfhd + tfdt + trun)) + _box(b"mdat", b""))
+
+    assert chunk.earliest_presentation_time == 1000
+    assert chunk.duration == 1000
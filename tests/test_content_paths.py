@@ -0,0 +1,39 @@
+"""Content path resolution tests."""
+
+import os
+import subprocess
+import sys
+from pathlib import Path
+
+import pytest
+
+from tigas.shared.content_paths import resolve_content_input
+
+
+def test_content_resolves_by_path_or_by_name(tmp_path) -> None:
+    trace_path = tmp_path / "custom.csv"
+    trace_path.write_text("1000\n", encoding="utf-8")
+
+    assert resolve_content_input("", "network_traces", ".csv") is None
+    assert resolve_content_input(str(trace_path), "network_traces", ".csv") == trace_path
+    assert resolve_content_input("lte_steps", "network_traces", ".csv").name == "lte_steps.csv"
+    with pytest.raises(FileNotFoundError, match="missing_trace"):
+        resolve_content_input("missing_trace", "network_traces", ".csv")
+
+
+def test_content_tools_do_not_import_the_renderer_stack() -> None:
+    source_root = Path(__file__).resolve().parents[1] / "src"
+    probe = (
+        "import sys\n"
+        "import tigas.evaluation.bundle, tigas.evaluation.run_abr_sim\n"
+        "print(sorted(name for name in ('numpy', 'tigas.orchestration.ablation_runner') if name in sys.modules))\n"
+    )
+    completed = subprocess.run(
+        [sys.executable, "-c", probe],
+        env={**os.environ, "PYTHONPATH": str(source_root)},
+        capture_output=True,
+        text=True,
+        check=True,
+    )
+
+    assert completed.stdout.strip() == "[]"
@@ -1,6 +1,14 @@
 """Contract tests for shared serialization boundaries."""
 
-from tigas.input_control.protocol import UplinkDatagramProtocol
+import dataclasses
+import json
+import math
+import random
+
+import pytest
+
+from tigas.input_control.protocol import MAX_DATAGRAM_BYTES, DatagramDecodeError, UplinkDatagramProtocol
+from tigas.input_control.schema import UPLINK_SCHEMA_PATH, uplink_datagram_schema
 from tigas.shared.types import UplinkDatagram
 
 
@@ -20,3 +28,102 @@ def test_uplink_protocol_roundtrip() -> None:
     assert decoded.seq_id == datagram.seq_id
     assert decoded.target_bitrate_kbps == datagram.target_bitrate_kbps
     assert decoded.camera_matrix_4x4 == datagram.camera_matrix_4x4
+    assert decoded.latency_budget_ms is None
+    assert b"latency_budget_ms" not in payload
+
+
+def test_uplink_protocol_roundtrips_latency_budget() -> None:
+    protocol = UplinkDatagramProtocol()
+    datagram = protocol.decode(_valid_payload())
+    datagram.latency_budget_ms = 50.0
+    datagram.media_time_ms = 1200.0
+
+    decoded = protocol.decode(protocol.encode(datagram))
+    assert decoded.latency_budget_ms == 50.0
+    assert decoded.media_time_ms == 1200.0
+
+
+def _valid_payload() -> bytes:
+    return UplinkDatagramProtocol().encode(
+        UplinkDatagram(
+            seq_id=1,
+            timestamp_ms=33.3,
+            camera_matrix_4x4=[1.0, 0.0, 0.0, 0.0, 0.0, 1.0, 0.0, 0.0, 0.0, 0.0, 1.0, 0.0, 0.0, 0.0, 0.0, 1.0],
+            requested_lod="sampled_50",
+            target_bitrate_kbps=2500,
+        )
+    )
+
+
+def test_uplink_protocol_rejects_contract_violations() -> None:
+    malformed = [
+        b"",
+        b"[]",
+        b"\xff\xfe",
+        b"[" * 1100,
+        b'{"seq_id":1}',
+        b" " * (MAX_DATAGRAM_BYTES + 1),
+        _valid_payload().replace(b'"sampled_50"', b'"ultra"'),
+        _valid_payload().replace(b'"seq_id":1', b'"seq_id":true'),
+        _valid_payload().replace(b'"seq_id":1', b'"seq_id":-1'),
+        _valid_payload().replace(b'"timestamp_ms":33.3', b'"timestamp_ms":NaN'),
+        _valid_payload().replace(b'"timestamp_ms":33.3', b'"timestamp_ms":1e999'),
+        _valid_payload().replace(b"1.0,0.0", b"1" + b"0" * 500 + b",0.0", 1),
+        _valid_payload().replace(b'"sampled_50"', b'["full"]'),
+        _valid_payload()[:-1] + b',"extra":0}',
+        _valid_payload()[:-1] + b',"latency_budget_ms":0}',
+        _valid_payload()[:-1] + b',"latency_budget_ms":"fast"}',
+        _valid_payload()[:-1] + b',"media_time_ms":-1}',
+    ]
+    protocol = UplinkDatagramProtocol()
+
+    for payload in malformed:
+        with pytest.raises(DatagramDecodeError):
+            protocol.decode(payload)
+        assert protocol.try_decode(payload) is None
+    assert protocol.rejected_count == len(malformed)
+
+
+def test_uplink_protocol_survives_mutated_datagrams() -> None:
+    protocol = UplinkDatagramProtocol()
+    valid = _valid_payload()
+    rng = random.Random(417)
+
+    schema = uplink_datagram_schema()
+    accepted = 0
+    for _ in range(2000):
+        mutated = bytearray(valid)
+        for _ in range(rng.randint(1, 8)):
+            op = rng.random()
+            position = rng.randrange(len(mutated) + 1)
+            if op < 0.4 and mutated:
+                mutated[min(position, len(mutated) - 1)] = rng.randrange(256)
+            elif op < 0.7:
+                mutated.insert(position, rng.choice(b'{}[],:"0123456789.-eE'))
+            elif mutated:
+                del mutated[min(position, len(mutated) - 1)]
+        datagram = protocol.try_decode(bytes(mutated))
+        if datagram is None:
+            continue
+        accepted += 1
+        # Whatever the decoder lets through must be a datagram the schema allows and that survives re-encoding.
+        assert dataclasses.asdict(protocol.decode(protocol.encode(datagram))) == dataclasses.asdict(datagram)
+        assert datagram.requested_lod in schema["properties"]["requested_lod"]["enum"]
+        assert len(datagram.camera_matrix_4x4) == 16
+        assert all(math.isfinite(value) for value in datagram.camera_matrix_4x4)
+        assert math.isfinite(datagram.timestamp_ms)
+        assert datagram.seq_id >= 0 and datagram.target_bitrate_kbps >= 0
+
+    assert accepted > 0
+    assert protocol.rejected_count > 0
+
+
+def test_checked_in_uplink_schema_matches_generated_schema() -> None:
+    with UPLINK_SCHEMA_PATH.open("r", encoding="utf-8") as handle:
+        checked_in = json.load(handle)
+    schema = uplink_datagram_schema()
+
+    assert checked_in == schema
+    assert set(schema["required"]) == set(json.loads(_valid_payload()))
+    assert "latency_budget_ms" in schema["properties"]
+    assert schema["properties"]["requested_lod"]["enum"] == ["full", "sampled_50", "quant_8bit", "adaptive"]
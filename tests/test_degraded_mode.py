@@ -0,0 +1,45 @@
+"""Degraded radio-mode ABR fallback tests."""
+
+import pytest
+
+from tigas.evaluation.abr_sim import AbrSimulator, summarize_timeline
+from tigas.evaluation.network_profiles import synthetic_network
+from tigas.intelligence.abr_client import AbrProfile
+from tigas.intelligence.degraded_mode import DegradedModeController
+
+_LADDER = {"name": "radio", "bitrates_kbps": [800, 1500, 4000], "lods": ["quant_8bit", "sampled_50", "full"]}
+
+
+def test_degraded_mode_uses_hysteresis_between_thresholds() -> None:
+    controller = DegradedModeController.for_profile(
+        AbrProfile.from_dict({**_LADDER, "degraded_entry_kbps": 400, "degraded_bitrate_kbps": 96})
+    )
+
+    assert controller.exit_kbps == 800.0
+    assert controller.lod == "quant_8bit"
+    active = [controller.update(ts, kbps) for ts, kbps in enumerate([900, 300, 600, 700, 850, 500, 100])]
+
+    assert active == [False, True, True, True, False, False, True]
+    intervals = controller.finish()
+    assert [(interval["start_ms"], interval["end_ms"]) for interval in intervals] == [(1.0, 4.0), (6.0, 6.0)]
+    assert intervals[0]["exit_kbps"] == 850
+    assert intervals[1]["exit_kbps"] is None
+
+
+def test_degraded_mode_is_off_by_default_and_validated() -> None:
+    assert DegradedModeController.for_profile(AbrProfile.from_dict(_LADDER)) is None
+    with pytest.raises(ValueError):
+        AbrProfile.from_dict({**_LADDER, "degraded_entry_kbps": 400, "degraded_bitrate_kbps": 900})
+    with pytest.raises(ValueError):
+        AbrProfile.from_dict({**_LADDER, "degraded_entry_kbps": 400, "degraded_exit_kbps": 300})
+
+
+def test_simulator_falls_to_degraded_mode_in_long_outage() -> None:
+    profile = AbrProfile.from_dict({**_LADDER, "degraded_entry_kbps": 500, "degraded_bitrate_kbps": 64})
+    steps = AbrSimulator(profile).run(synthetic_network("outage", length=8))
+    degraded = [step for step in steps if step.limited_by == "degraded_mode"]
+
+    assert degraded
+    assert {(step.bitrate_kbps, step.lod) for step in degraded} == {(64, "quant_8bit")}
+    assert summarize_timeline(profile, steps)["degraded_chunks"] == len(degraded)
+    assert steps[-1].limited_by != "degraded_mode"
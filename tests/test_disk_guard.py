@@ -0,0 +1,84 @@
+"""Artifact disk-space guard tests."""
+
+import contextlib
+import io
+from pathlib import Path
+
+from tigas.evaluation.disk_guard import DiskSpaceGuard
+from tigas.evaluation.evaluator import EvaluationRunner
+from tigas.orchestration.ablation_runner import HeadlessAblationRunner
+from tigas.shared.types import ExperimentConfig, RawFrame
+
+
+def test_disk_guard_pauses_writes_below_threshold(tmp_path) -> None:
+    guard = DiskSpaceGuard(tmp_path, min_free_mb=1000.0, check_every=2)
+    free_values = iter([5000.0, 500.0])
+    guard.free_mb = lambda: next(free_values)
+
+    stderr = io.StringIO()
+    with contextlib.redirect_stderr(stderr):
+        allowed = [guard.allow_write() for _ in range(5)]
+
+    assert allowed == [True, True, False, False, False]
+    assert guard.status.tripped
+    assert guard.status.tripped_at_write == 2
+    assert guard.status.free_mb_at_trip == 500.0
+    assert guard.status.skipped_writes == 3
+    assert "pausing frame dumps" in stderr.getvalue()
+
+
+def test_disk_guard_disabled_with_zero_threshold(tmp_path) -> None:
+    guard = DiskSpaceGuard(tmp_path, min_free_mb=0.0)
+
+    assert all(guard.allow_write() for _ in range(3))
+    assert not guard.status.tripped
+
+
+class _FlatRenderer:
+    backend_name = "stub"
+    loaded_point_count = 1
+    scene_radius = 1.0
+    scene_center = (0.0, 0.0, 0.0)
+
+    def initialize(self) -> None:
+        pass
+
+    def render(self, request):
+        return RawFrame(frame_id=0, width=4, height=4, pixel_format="rgb24", is_keyframe_hint=False, data=bytes(48))
+
+    def shutdown(self) -> None:
+        pass
+
+
+class _FlatRendererRunner(HeadlessAblationRunner):
+    def _build_renderer(self, config: ExperimentConfig, point_cloud_path: Path):
+        return _FlatRenderer()
+
+    def _resolve_point_cloud_path(self, config: ExperimentConfig) -> Path:
+        return Path("stub.ply")
+
+
+def test_evaluation_run_completes_when_guard_skips_every_frame(tmp_path, monkeypatch) -> None:
+    monkeypatch.setattr(DiskSpaceGuard, "free_mb", lambda self: 1.0)
+    runner = EvaluationRunner(min_free_mb=1024.0)
+    runner.runtime_runner = _FlatRendererRunner()
+    config = ExperimentConfig(
+        trace_path="",
+        codec="libx264",
+        predictor="noop",
+        network_profile="lte",
+        default_lod="full",
+        num_frames=5,
+        seed=429,
+        deterministic=True,
+        output_dir=str(tmp_path),
+    )
+
+    with contextlib.redirect_stderr(io.StringIO()):
+        summary = runner.run_one(config, str(tmp_path)).summary
+
+    assert summary["disk_guard"]["tripped_at_write"] == 0
+    assert summary["disk_guard"]["skipped_writes"] == 5
+    assert summary["video_path"] is None
+    assert summary["video_skipped_reason"] == "disk_guard_skipped_all_frames"
+    assert not list(Path(summary["frames_dir"]).glob("*.ppm"))
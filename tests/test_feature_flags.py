@@ -0,0 +1,28 @@
+"""Feature flag registry tests."""
+
+import pytest
+
+from tigas.shared.feature_flags import FEATURE_FLAGS, parse_flag_overrides, resolve_feature_flags
+
+
+def test_resolve_feature_flags_returns_defaults_and_overrides() -> None:
+    defaults = resolve_feature_flags()
+    assert defaults == {name: flag.default for name, flag in sorted(FEATURE_FLAGS.items())}
+
+    effective = resolve_feature_flags({"abr_server_guardrail": "false"})
+    assert effective["abr_server_guardrail"] is False
+    assert effective["abr_freeze_window"] is True
+
+
+def test_parse_flag_overrides_coerces_values() -> None:
+    overrides = parse_flag_overrides(["abr_freeze_window=off", " abr_degraded_mode = 1 "])
+    assert overrides == {"abr_degraded_mode": True, "abr_freeze_window": False}
+
+
+def test_feature_flags_reject_unknown_and_malformed_values() -> None:
+    with pytest.raises(ValueError, match="Unknown feature flag"):
+        resolve_feature_flags({"no_such_flag": True})
+    with pytest.raises(ValueError, match="expects a boolean"):
+        parse_flag_overrides(["abr_freeze_window=maybe"])
+    with pytest.raises(ValueError, match="NAME=VALUE"):
+        parse_flag_overrides(["abr_freeze_window"])
@@ -1,5 +1,7 @@
 """Headless trace generation tests."""
 
+import pytest
+
 from tigas.input_control.headless_replayer import HeadlessTraceReplayer
 
 
@@ -22,3 +24,58 @@ def test_generate_orbit_samples_and_datagrams() -> None:
     assert len(datagrams) == 12
     assert datagrams[-1].seq_id == 11
     assert datagrams[-1].target_bitrate_kbps == 3500
+
+
+def test_trace_schedule_parsing() -> None:
+    schedule = HeadlessTraceReplayer.parse_trace_schedule("Circular, Linear@5000,/tmp/a@b.json")
+    assert schedule == [("Circular", None), ("Linear", 5000.0), ("/tmp/a@b.json", None)]
+
+
+def test_trace_schedule_rejects_start_times_that_go_backwards() -> None:
+    for spec in ("a@5000,b@1000", "a@1000,b,c@1000"):
+        with pytest.raises(ValueError, match="must increase"):
+            HeadlessTraceReplayer.parse_trace_schedule(spec)
+
+
+def test_splice_traces_rebases_and_records_switches() -> None:
+    replayer = HeadlessTraceReplayer()
+    first = replayer.generate_orbit_samples(center=(0.0, 0.0, 0.0), radius=1.0, num_frames=10, fps=10)
+    second = replayer.generate_orbit_samples(center=(0.0, 0.0, 0.0), radius=2.0, num_frames=10, fps=10)
+
+    samples, switches = replayer.splice_traces([("a", first, None), ("b", second, 500.0), ("c", first, None)])
+
+    assert [switch["to_trace"] for switch in switches] == ["b", "c"]
+    assert switches[0]["timestamp_ms"] == 500.0
+    assert switches[1]["timestamp_ms"] == 1500.0
+    assert len(samples) == 5 + 10 + 10
+    timestamps = [sample.timestamp_ms for sample in samples]
+    assert timestamps == sorted(timestamps)
+
+
+def test_splice_traces_rejects_switches_before_the_previous_trace_starts() -> None:
+    replayer = HeadlessTraceReplayer()
+    trace = replayer.generate_orbit_samples(center=(0.0, 0.0, 0.0), radius=1.0, num_frames=10, fps=10)
+
+    # B follows A implicitly at 1000 ms, so C@500 would drop B and switch back in time.
+    with pytest.raises(ValueError, match="'C' is scheduled at 500 ms, not after 'B' starts at 1000 ms"):
+        replayer.splice_traces([("A", trace, None), ("B", trace, None), ("C", trace, 500.0)])
+    with pytest.raises(ValueError, match="'B' is scheduled at 0 ms"):
+        replayer.splice_traces([("A", trace, None), ("B", trace, 0.0)])
+
+
+def test_resample_interpolates_pose_at_fixed_rate() -> None:
+    replayer = HeadlessTraceReplayer()
+    samples = replayer.generate_orbit_samples(center=(0.0, 0.0, 0.0), radius=1.0, num_frames=4, fps=10)
+
+    resampled = replayer.resample(samples, rate_hz=20.0)
+
+    assert [round(sample.timestamp_ms, 6) for sample in resampled] == [0.0, 50.0, 100.0, 150.0, 200.0, 250.0, 300.0]
+    assert resampled[2].camera_matrix_4x4 == pytest.approx(samples[1].camera_matrix_4x4, abs=1e-9)
+
+    midpoint = resampled[1].camera_matrix_4x4
+    position = (midpoint[3], midpoint[7], midpoint[11])
+    expected = [(samples[0].camera_matrix_4x4[i] + samples[1].camera_matrix_4x4[i]) / 2.0 for i in (3, 7, 11)]
+    assert list(position) == pytest.approx(expected)
+    rotation_rows = [midpoint[0:3], midpoint[4:7], midpoint[8:11]]
+    for row in rotation_rows:
+        assert sum(value * value for value in row) == pytest.approx(1.0)
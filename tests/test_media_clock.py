@@ -0,0 +1,37 @@
+"""Session-to-media time mapping tests."""
+
+from tigas.intelligence.media_clock import SOURCE_CLIENT_MARKERS, SOURCE_DERIVED, MediaClock
+from tigas.intelligence.stall_detector import StallInterval
+
+
+def test_media_time_stops_inside_stalls() -> None:
+    clock = MediaClock(start_ms=100.0)
+    clock.finish(
+        [
+            StallInterval(start_ms=1100.0, end_ms=1600.0, cause="gap"),
+            StallInterval(start_ms=2600.0, end_ms=2700.0, cause="buffer_empty"),
+        ]
+    )
+
+    assert clock.source == SOURCE_DERIVED
+    assert clock.to_media_ms(600.0) == 500.0
+    assert clock.to_media_ms(1400.0) == 1000.0
+    assert clock.to_media_ms(2100.0) == 1500.0
+    assert clock.to_media_ms(3700.0) == 3000.0
+    assert MediaClock.from_dict(clock.to_dict()).to_media_ms(2100.0) == 1500.0
+    assert clock.to_session_ms(500.0) == 600.0
+    assert clock.to_session_ms(1000.0) == 1100.0
+    assert clock.to_session_ms(1500.0) == 2100.0
+    assert clock.to_session_ms(3000.0) == 3700.0
+
+
+def test_client_markers_override_derived_mapping() -> None:
+    clock = MediaClock(start_ms=0.0)
+    clock.mark(1000.0, 400.0)
+    clock.mark(2000.0, 1400.0)
+    clock.finish([StallInterval(start_ms=100.0, end_ms=900.0, cause="gap")])
+
+    assert clock.source == SOURCE_CLIENT_MARKERS
+    assert clock.to_media_ms(1500.0) == 900.0
+    assert clock.to_media_ms(2500.0) == 1900.0
+    assert clock.to_media_ms(500.0) == 0.0
@@ -0,0 +1,24 @@
+"""Pose uplink rate limiter tests."""
+
+import pytest
+
+from tigas.input_control.rate_limit import PoseRateLimiter, advertised_pose_rate_hz
+
+
+def test_rate_limiter_drops_excess_pose_datagrams() -> None:
+    limiter = PoseRateLimiter(max_rate_hz=100.0)
+
+    admitted = [limiter.admit(timestamp_ms) for timestamp_ms in [i * 2.0 for i in range(50)]]
+
+    assert sum(admitted) == 10
+    assert limiter.dropped_count == 40
+    assert limiter.advertise() == {"max_pose_rate_hz": 100.0}
+    with pytest.raises(ValueError):
+        PoseRateLimiter(max_rate_hz=0.0)
+
+
+def test_advertised_rate_follows_horizon_and_load() -> None:
+    assert advertised_pose_rate_hz(prediction_horizon_ms=50.0) == pytest.approx(80.0)
+    assert advertised_pose_rate_hz(prediction_horizon_ms=50.0, cpu_load=0.5) == pytest.approx(40.0)
+    assert advertised_pose_rate_hz(prediction_horizon_ms=5.0) == pytest.approx(240.0)
+    assert advertised_pose_rate_hz(prediction_horizon_ms=50.0, cpu_load=1.0) == pytest.approx(15.0)
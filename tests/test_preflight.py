@@ -0,0 +1,101 @@
+"""Headless preflight validation tests."""
+
+import json
+
+from tigas.orchestration.preflight import preflight_report, run_preflight
+from tigas.shared.types import ExperimentConfig
+
+
+def _config(**overrides) -> ExperimentConfig:
+    fields = {
+        "trace_path": "Circular",
+        "codec": "libx264",
+        "predictor": "noop",
+        "network_profile": "wifi",
+        "default_lod": "full",
+        "network_trace_path": "lte_steps",
+        "abr_profile_path": "throughput",
+    }
+    fields.update(overrides)
+    return ExperimentConfig(**fields)
+
+
+def test_preflight_passes_with_repository_traces(tmp_path) -> None:
+    asset = tmp_path / "scene.ply"
+    asset.write_bytes(b"ply\nformat binary_little_endian 1.0\nelement vertex 3\nend_header\n")
+
+    report = preflight_report(run_preflight(_config(asset_path=str(asset), scenario_path="impairment_pin")))
+
+    assert report["status"] == "ok"
+    assert [check["name"] for check in report["checks"]] == [
+        "asset",
+        "movement_trace",
+        "network_trace",
+        "abr_profile",
+        "scenario",
+        "tc",
+    ]
+
+
+def test_preflight_reports_missing_asset_and_unsorted_ladder(tmp_path) -> None:
+    profile = tmp_path / "bad.json"
+    profile.write_text(
+        json.dumps({"algorithm": "throughput", "bitrates_kbps": [2000, 800], "lods": ["full", "quant_8bit"]}),
+        encoding="utf-8",
+    )
+
+    checks = {
+        check.name: check
+        for check in run_preflight(
+            _config(asset_path=str(tmp_path / "missing.ply"), abr_profile_path=str(profile), enable_tc=True)
+        )
+    }
+
+    assert not checks["asset"].ok
+    assert not checks["abr_profile"].ok
+    assert not checks["tc"].ok
+    assert checks["network_trace"].ok
+
+
+def test_preflight_rejects_scenario_pins_outside_the_ladder(tmp_path) -> None:
+    scenario = tmp_path / "pin.json"
+    scenario.write_text(
+        json.dumps({"name": "pin", "actions": [{"at_s": 1, "action": "pin_profile", "min_profile": "p9"}]}),
+        encoding="utf-8",
+    )
+
+    checks = {check.name: check for check in run_preflight(_config(scenario_path=str(scenario)))}
+
+    assert not checks["scenario"].ok
+    assert "p9" in checks["scenario"].detail
+
+
+def test_preflight_reports_malformed_vertex_count(tmp_path) -> None:
+    asset = tmp_path / "scene.ply"
+    asset.write_bytes(b"ply\nformat binary_little_endian 1.0\nelement vertex abc\nend_header\n")
+
+    (check,) = [check for check in run_preflight(_config(asset_path=str(asset))) if check.name == "asset"]
+
+    assert not check.ok
+    assert "Malformed PLY vertex element" in check.detail
+
+
+def test_preflight_rejects_schedule_switching_before_an_implicit_trace_starts() -> None:
+    checks = {check.name: check for check in run_preflight(_config(trace_path="Circular,Linear@0"))}
+    check = checks["movement_trace"]
+
+    assert not check.ok
+    assert "'Linear' is scheduled at 0 ms" in check.detail
+
+
+def test_preflight_reports_malformed_profile_payloads(tmp_path) -> None:
+    string_rung = tmp_path / "string_rung.json"
+    string_rung.write_text(json.dumps({"bitrates_kbps": [800, "fast"]}), encoding="utf-8")
+    not_an_object = tmp_path / "list.json"
+    not_an_object.write_text(json.dumps([800, 1500]), encoding="utf-8")
+
+    for profile in (string_rung, not_an_object):
+        config = _config(abr_profile_path=str(profile), scenario_path="impairment_pin")
+        checks = {check.name: check for check in run_preflight(config)}
+        assert not checks["abr_profile"].ok
+        assert checks["abr_profile"].detail.startswith("Malformed ABR profile")
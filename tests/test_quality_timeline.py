@@ -0,0 +1,36 @@
+"""Per-second quality timeline tests."""
+
+import pytest
+
+from tigas.intelligence.quality_timeline import QualityTimeline
+from tigas.intelligence.stall_detector import StallInterval
+
+
+def test_quality_timeline_buckets_frames_and_overlaps_stalls() -> None:
+    timeline = QualityTimeline(bucket_ms=1000.0)
+    timeline.observe(0.0, "full", 4000)
+    timeline.observe(500.0, "sampled_50", 1500)
+    timeline.observe(900.0, "sampled_50", 1500)
+    timeline.observe(2100.0, "full", 4000)
+
+    rows = timeline.finish([StallInterval(start_ms=800.0, end_ms=2200.0, cause="gap")])
+
+    assert [row["index"] for row in rows] == [0, 1, 2]
+    assert rows[0]["lod"] == "sampled_50"
+    assert rows[0]["bitrate_kbps_mean"] == pytest.approx(7000 / 3)
+    assert rows[0]["stall_ms"] == pytest.approx(200.0)
+    assert rows[1]["frames"] == 0
+    assert rows[1]["lod"] is None
+    assert rows[1]["stall_ms"] == pytest.approx(1000.0)
+    assert rows[2]["stall_ms"] == pytest.approx(200.0)
+
+
+def test_quality_timeline_counts_frozen_stalls_in_their_window() -> None:
+    timeline = QualityTimeline(bucket_ms=1000.0)
+    timeline.observe(900.0, "full", 4000)
+    timeline.observe(1100.0, "full", 4000)
+    timeline.add_stall(950.0, 1500.0)
+
+    rows = timeline.finish()
+
+    assert [row["stall_ms"] for row in rows] == [1500.0, 0.0]
@@ -0,0 +1,62 @@
+"""Run artifact bundle tests."""
+
+import json
+import tarfile
+
+from tigas.evaluation.bundle import BUNDLE_MANIFEST, bundle_run
+
+
+def test_bundle_run_packages_artifacts_and_inputs(tmp_path) -> None:
+    run_dir = tmp_path / "20260101_000000_full_960x540_s1000_q8"
+    (run_dir / "frames").mkdir(parents=True)
+    (run_dir / "frames" / "frame_00000.ppm").write_bytes(b"P6\n1 1\n255\n\x00\x00\x00")
+    (run_dir / "frame_metrics.csv").write_text("frame_index\n0\n", encoding="utf-8")
+    summary = {
+        "abr_switches": [],
+        "config": {
+            "trace_path": "Circular,Linear@2000",
+            "network_trace_path": "lte_steps",
+            "abr_profile_path": "bola",
+            "asset_path": "scene.ply",
+        },
+    }
+    (run_dir / "summary.json").write_text(json.dumps(summary), encoding="utf-8")
+
+    archive_path = bundle_run(run_dir)
+
+    assert archive_path == tmp_path / f"{run_dir.name}.tar.gz"
+    with tarfile.open(archive_path, "r:gz") as archive:
+        names = set(archive.getnames())
+        manifest = json.load(archive.extractfile(f"{run_dir.name}/{BUNDLE_MANIFEST}"))
+
+    root = run_dir.name
+    assert f"{root}/summary.json" in names
+    assert f"{root}/frames/frame_00000.ppm" not in names
+    assert {
+        f"{root}/inputs/movement_traces/Circular.json",
+        f"{root}/inputs/movement_traces/Linear.json",
+        f"{root}/inputs/network_traces/lte_steps.csv",
+        f"{root}/inputs/abr_profiles/bola.json",
+    } <= names
+    assert manifest["asset_path"] == "scene.ply"
+    assert all(len(entry["sha256"]) == 64 for entry in manifest["files"])
+
+
+def test_bundle_inputs_with_the_same_name_do_not_collide(tmp_path) -> None:
+    run_dir = tmp_path / "run"
+    run_dir.mkdir()
+    trace_dir = tmp_path / "traces"
+    trace_dir.mkdir()
+    trace_path = trace_dir / "x.json"
+    trace_path.write_text('{"trace": []}', encoding="utf-8")
+    scenario_path = tmp_path / "x.json"
+    scenario_path.write_text('{"actions": []}', encoding="utf-8")
+    summary = {"config": {"trace_path": f"{trace_path}@0,{trace_path}@500", "scenario_path": str(scenario_path)}}
+    (run_dir / "summary.json").write_text(json.dumps(summary), encoding="utf-8")
+
+    with tarfile.open(bundle_run(run_dir), "r:gz") as archive:
+        inputs = sorted(name for name in archive.getnames() if "/inputs/" in name)
+        scenario = archive.extractfile("run/inputs/scenarios/x.json").read()
+
+    assert inputs == ["run/inputs/movement_traces/x.json", "run/inputs/scenarios/x.json"]
+    assert scenario == b'{"actions": []}'
@@ -0,0 +1,70 @@
+"""Run comparison report tests."""
+
+import json
+
+import pytest
+
+from tigas.evaluation.report import build_comparison_report, render_markdown
+
+
+def _write_summary(run_dir, **fields) -> None:
+    run_dir.mkdir(parents=True, exist_ok=True)
+    payload = {
+        "frames_rendered": 100,
+        "render_time_ms": {"mean": 10.0},
+        "effective_fps": 30.0,
+        **fields,
+    }
+    (run_dir / "summary.json").write_text(json.dumps(payload), encoding="utf-8")
+
+
+def test_build_comparison_report_aggregates_groups(tmp_path) -> None:
+    _write_summary(
+        tmp_path / "throughput" / "run_a",
+        abr_profile="throughput",
+        ssim_vs_full_mean=0.9,
+        abr_switch_count=4,
+        abr_buffer_empty_frames=10,
+        bandwidth_usage={"total_bytes": 3000, "by_transport": {"datagram": 1000, "frame": 2000}},
+    )
+    _write_summary(
+        tmp_path / "throughput" / "run_b",
+        abr_profile="throughput",
+        ssim_vs_full_mean=0.8,
+        abr_switch_count=2,
+        abr_buffer_empty_frames=0,
+        bandwidth_usage={"total_bytes": 500, "by_transport": {"frame": 500}},
+    )
+    _write_summary(tmp_path / "bola" / "run_a", abr_profile="bola", abr_switch_count=1)
+
+    report = build_comparison_report([str(tmp_path / "throughput"), str(tmp_path / "bola")])
+
+    throughput, bola = report["groups"]
+    assert throughput["num_runs"] == 2
+    assert throughput["ssim_vs_full_mean"] == pytest.approx(0.85)
+    assert throughput["abr_switch_count_total"] == 6
+    assert throughput["stall_frame_ratio"] == pytest.approx(0.05)
+    assert bola["ssim_vs_full_mean"] is None
+    assert bola["stall_frame_ratio"] is None
+    assert throughput["bytes_total"] == 3500
+    assert throughput["bytes_by_transport"] == {"datagram": 1000, "frame": 2500}
+    assert bola["bytes_total"] == 0
+    assert "n/a" in render_markdown(report)
+
+
+def test_build_comparison_report_requires_two_groups(tmp_path) -> None:
+    _write_summary(tmp_path / "only")
+    with pytest.raises(ValueError):
+        build_comparison_report([str(tmp_path / "only")])
+
+
+def test_build_comparison_report_lists_feature_flag_values(tmp_path) -> None:
+    _write_summary(tmp_path / "on" / "run_a", feature_flags={"abr_degraded_mode": True})
+    _write_summary(tmp_path / "mixed" / "run_a", feature_flags={"abr_degraded_mode": True})
+    _write_summary(tmp_path / "mixed" / "run_b", feature_flags={"abr_degraded_mode": False})
+
+    report = build_comparison_report([str(tmp_path / "on"), str(tmp_path / "mixed")])
+
+    on, mixed = report["groups"]
+    assert on["feature_flags"] == {"abr_degraded_mode": [True]}
+    assert mixed["feature_flags"] == {"abr_degraded_mode": [True, False]}
@@ -0,0 +1,93 @@
+"""Scenario file parsing and playback tests."""
+
+from pathlib import Path
+
+import pytest
+
+from tigas.intelligence.abr_client import AbrProfile
+from tigas.orchestration.scenario import Scenario, ScenarioAction, ScenarioPlayer, load_scenario, pin_bounds
+
+
+def test_repository_scenarios_load() -> None:
+    scenario_dir = Path(__file__).resolve().parents[1] / "scenarios"
+    scenarios = [load_scenario(path) for path in sorted(scenario_dir.glob("*.json"))]
+
+    assert scenarios
+    for scenario in scenarios:
+        times = [action.at_ms for action in scenario.actions]
+        assert times == sorted(times)
+
+
+def test_player_fires_each_action_once_relative_to_start() -> None:
+    scenario = Scenario.from_dict(
+        {
+            "name": "steps",
+            "actions": [
+                {"at_s": 2, "action": "clear_bandwidth"},
+                {"at_ms": 500, "action": "set_bandwidth_kbps", "kbps": 1200},
+                {"at_ms": 500, "action": "marker", "label": "drop"},
+            ],
+        }
+    )
+    player = ScenarioPlayer(scenario, start_ms=1000.0)
+
+    assert player.due(1400.0) == []
+    assert [action.action for action in player.due(1500.0)] == ["set_bandwidth_kbps", "marker"]
+    assert player.due(1600.0) == []
+    assert [action.action for action in player.due(5000.0)] == ["clear_bandwidth"]
+    assert player.pending_count == 0
+    assert player.fired[0] == {"at_ms": 500.0, "timestamp_ms": 1500.0, "action": "set_bandwidth_kbps", "kbps": 1200}
+
+
+def test_invalid_scenario_actions_are_rejected() -> None:
+    invalid = [
+        {"at_s": 1, "action": "broadcast"},
+        {"action": "marker", "label": "no time"},
+        {"at_s": -1, "action": "clear_bandwidth"},
+        {"at_s": 1, "action": "set_bandwidth_kbps"},
+        {"at_s": 1, "action": "set_bandwidth_kbps", "kbps": 0},
+        {"at_s": 1, "action": "pin_profile"},
+    ]
+    for payload in invalid:
+        with pytest.raises(ValueError):
+            Scenario.from_dict({"actions": [payload]})
+
+
+def test_yaml_scenarios_load_like_json(tmp_path) -> None:
+    pytest.importorskip("yaml")
+    scenario_path = tmp_path / "steps.yaml"
+    scenario_path.write_text(
+        "name: steps\n"
+        "actions:\n"
+        "  - {at_s: 1.5, action: set_bandwidth_kbps, kbps: 1500}\n"
+        "  - {at_s: 2.5, action: pin_profile, min_profile: p1, max_profile: p1}\n",
+        encoding="utf-8",
+    )
+
+    scenario = load_scenario(scenario_path)
+
+    assert scenario.name == "steps"
+    assert [(action.at_ms, action.action) for action in scenario.actions] == [
+        (1500.0, "set_bandwidth_kbps"),
+        (2500.0, "pin_profile"),
+    ]
+
+    scenario_path.write_text("- at_s: 1\n", encoding="utf-8")
+    with pytest.raises(ValueError, match="mapping"):
+        load_scenario(scenario_path)
+
+
+def test_pin_bounds_are_intersected_with_the_run_bounds() -> None:
+    profile = AbrProfile(
+        name="ladder",
+        algorithm="throughput",
+        bitrates_kbps=[800, 1500, 2500, 4000, 6000],
+        lods=["quant_8bit", "sampled_50", "sampled_50", "full", "full"],
+    )
+    pin = ScenarioAction.from_dict({"at_s": 1, "action": "pin_profile", "min_profile": "p1"})
+
+    assert pin_bounds(pin, profile) == ("p1", "p4")
+    assert pin_bounds(pin, profile, max_profile="p2") == ("p1", "p2")
+    assert pin_bounds(pin, profile, min_profile="p3") == ("p3", "p4")
+    with pytest.raises(ValueError, match="does not overlap"):
+        pin_bounds(pin, profile, max_profile="p0")
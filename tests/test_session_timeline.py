@@ -0,0 +1,71 @@
+"""HTML session timeline export tests."""
+
+import json
+
+from tigas.evaluation.timeline_html import TIMELINE_HTML_NAME, build_session_timeline, export_session_timeline
+
+
+def _write_run(run_dir, **extra) -> None:
+    run_dir.mkdir(parents=True)
+    summary = {
+        "output_dir": str(run_dir),
+        "abr_profile": "bola<test>",
+        "trace_source": "generated_orbit",
+        "quality_timeline": [
+            {"start_ms": 0.0, "bitrate_kbps_mean": 2500.0},
+            {"start_ms": 1000.0, "bitrate_kbps_mean": 800.0},
+        ],
+        "abr_switches": [
+            {"timestamp_ms": 1000.0, "from_kbps": 2500, "to_kbps": 800, "lod": "quant_8bit", "server_reason": "client"}
+        ],
+        "trace_switches": [{"timestamp_ms": 1500.0, "from_trace": "a", "to_trace": "b"}],
+        "stall_intervals": [{"start_ms": 1200.0, "end_ms": 2400.0, "duration_ms": 1200.0, "cause": "buffer_empty"}],
+        **extra,
+    }
+    (run_dir / "summary.json").write_text(json.dumps(summary), encoding="utf-8")
+    rows = ["frame_id,timestamp_ms,render_time_ms,coverage,brightness,ssim_vs_full"]
+    rows += [f"{index},{index * 100.0},{1.0 + index},0.5,0.5,nan" for index in range(20)]
+    (run_dir / "frame_metrics.csv").write_text("\n".join(rows) + "\n", encoding="utf-8")
+
+
+def test_session_timeline_aligns_series_and_events(tmp_path) -> None:
+    run_dir = tmp_path / "run"
+    _write_run(run_dir)
+
+    timeline = build_session_timeline(run_dir)
+
+    assert timeline["end_ms"] == 2400.0
+    assert timeline["pose_rate_hz"] == [(0.0, 10.0), (1000.0, 10.0)]
+    assert len(timeline["render_time_ms"]) == 20
+    assert timeline["bitrate_kbps"][1] == (1000.0, 800.0)
+
+
+def test_session_timeline_html_is_self_contained(tmp_path) -> None:
+    run_dir = tmp_path / "run"
+    _write_run(run_dir)
+
+    html_path = export_session_timeline(run_dir)
+    document = html_path.read_text(encoding="utf-8")
+
+    assert html_path.name == TIMELINE_HTML_NAME
+    assert "<svg" in document and "<script" not in document
+    assert "2500 -&gt; 800 kbps" in document
+    assert "trace a -&gt; b" in document
+    assert "bola&lt;test&gt;" in document
+
+
+def test_session_timeline_media_axis_collapses_stalls(tmp_path) -> None:
+    run_dir = tmp_path / "run"
+    media_clock = {"source": "derived_from_stalls", "anchors": [[0.0, 0.0], [1200.0, 1200.0], [2400.0, 1200.0]]}
+    _write_run(run_dir, media_clock=media_clock)
+
+    timeline = build_session_timeline(run_dir, axis="media")
+
+    assert timeline["axis"] == "media"
+    assert timeline["stalls"][0]["start_ms"] == timeline["stalls"][0]["end_ms"] == 1200.0
+    assert timeline["trace_switches"][0]["timestamp_ms"] == 1200.0
+    assert timeline["render_time_ms"][-1][0] == 1200.0
+    assert timeline["bitrate_kbps"][1] == (1000.0, 800.0)
+
+    session = build_session_timeline(run_dir)
+    assert session["bitrate_kbps"] == [(0.0, 2500.0), (1000.0, 800.0)]
@@ -0,0 +1,57 @@
+"""Shutdown report tests."""
+
+import contextlib
+import io
+import json
+
+from tigas.orchestration.shutdown_report import SHUTDOWN_REPORT_NAME, ShutdownReport
+
+
+def test_shutdown_report_aggregates_runs_and_errors(tmp_path) -> None:
+    shutdown = ShutdownReport()
+    shutdown.record_run(
+        {
+            "frames_rendered": 10,
+            "bytes_by_lod": {"full": 1000, "quant_8bit": 200},
+            "pose_datagrams_dropped": 3,
+            "trace_cache": {"entries": 1, "hits": 0, "misses": 1},
+        }
+    )
+    shutdown.record_run(
+        {
+            "frames_rendered": 5,
+            "bytes_by_lod": {"full": 500},
+            "pose_datagrams_dropped": 1,
+            "trace_cache": {"entries": 1, "hits": 1, "misses": 1},
+        }
+    )
+    shutdown.record_error(RuntimeError("boom"))
+
+    stdout = io.StringIO()
+    stderr = io.StringIO()
+    with contextlib.redirect_stdout(stdout), contextlib.redirect_stderr(stderr):
+        report = shutdown.write(tmp_path / "out")
+
+    assert report["status"] == "error"
+    assert report["sessions_served"] == 2
+    assert report["frames_rendered"] == 15
+    assert report["bytes_by_lod"] == {"full": 1500, "quant_8bit": 200}
+    assert report["bytes_total"] == 1700
+    assert report["errors_by_class"] == {"RuntimeError": 1}
+    assert report["dropped_datagrams"] == 4
+    assert report["trace_cache"]["hits"] == 1
+
+    written = json.loads((tmp_path / "out" / SHUTDOWN_REPORT_NAME).read_text(encoding="utf-8"))
+    printed = json.loads(stderr.getvalue().strip().splitlines()[-1])
+    assert written["sessions_served"] == 2
+    assert printed["shutdown_report"]["bytes_total"] == 1700
+    # Stdout carries only the run summary, so it stays one parseable JSON document.
+    assert stdout.getvalue() == ""
+
+
+def test_shutdown_report_is_ok_without_errors() -> None:
+    report = ShutdownReport().to_dict()
+
+    assert report["status"] == "ok"
+    assert report["sessions_served"] == 0
+    assert report["uptime_s"] >= 0.0
@@ -0,0 +1,33 @@
+"""Stall inference heuristic tests."""
+
+from tigas.intelligence.stall_detector import StallDetector, median_interval_ms
+
+
+def test_stall_detector_merges_buffer_empty_frames() -> None:
+    detector = StallDetector()
+    detector.observe(timestamp_ms=0.0, interval_ms=33.3, nominal_interval_ms=33.3, buffer_level_ms=100.0)
+    detector.observe(timestamp_ms=33.3, interval_ms=33.3, nominal_interval_ms=33.3, buffer_level_ms=0.0)
+    detector.observe(timestamp_ms=66.6, interval_ms=33.3, nominal_interval_ms=33.3, buffer_level_ms=0.0)
+    detector.observe(timestamp_ms=99.9, interval_ms=33.3, nominal_interval_ms=33.3, buffer_level_ms=50.0)
+
+    intervals = detector.finish()
+    assert len(intervals) == 1
+    assert intervals[0].cause == "buffer_empty"
+    assert intervals[0].start_ms == 0.0
+    assert intervals[0].end_ms == 66.6
+
+
+def test_stall_detector_flags_control_gaps() -> None:
+    detector = StallDetector(gap_factor=2.0)
+    assert detector.observe(timestamp_ms=0.0, interval_ms=10.0, nominal_interval_ms=10.0) is None
+    assert detector.observe(timestamp_ms=100.0, interval_ms=100.0, nominal_interval_ms=10.0) == "gap"
+
+    intervals = detector.finish()
+    assert len(intervals) == 1
+    assert intervals[0].start_ms == 10.0
+    assert intervals[0].to_dict()["duration_ms"] == 90.0
+
+
+def test_median_interval_follows_schedule_cadence() -> None:
+    assert median_interval_ms([0.0, 70.0, 140.0, 800.0, 870.0], fallback_ms=33.3) == 70.0
+    assert median_interval_ms([5.0], fallback_ms=33.3) == 33.3
@@ -0,0 +1,37 @@
+"""Startup connectivity diagnostics tests."""
+
+import socket
+
+from tigas.instrumentation.startup_diagnostics import bind_failure_diagnostics, unreachable_diagnostics
+from tigas.instrumentation.udp_probe import UdpProbeResponder
+
+
+def test_bind_conflict_names_the_port_owner_lookup() -> None:
+    with socket.socket(socket.AF_INET, socket.SOCK_DGRAM) as occupant:
+        occupant.bind(("127.0.0.1", 0))
+        port = occupant.getsockname()[1]
+        try:
+            UdpProbeResponder(host="127.0.0.1", port=port)
+        except OSError as exc:
+            diagnostics = bind_failure_diagnostics("127.0.0.1", port, exc)
+        else:
+            raise AssertionError("expected the second bind to fail")
+
+    assert diagnostics.issue == "bind_failed"
+    assert "already in use" in diagnostics.hints[0]
+    assert any(f"sport = :{port}" in hint for hint in diagnostics.hints)
+
+
+def test_idle_diagnostics_separate_listening_from_unreachable() -> None:
+    responder = UdpProbeResponder(host="127.0.0.1", port=0).start()
+    port = responder.address[1]
+    try:
+        listening = unreachable_diagnostics("127.0.0.1", port, idle_s=5.0)
+    finally:
+        responder.stop()
+    silent = unreachable_diagnostics("127.0.0.1", port, idle_s=5.0, timeout_s=0.05)
+
+    assert listening.checks["loopback"]["reachable"] is True
+    assert any("loopback only" in hint for hint in listening.hints)
+    assert silent.checks["loopback"]["reachable"] is False
+    assert any("firewall" in hint for hint in silent.hints)
@@ -0,0 +1,115 @@
+"""Delta-encoded control state replication tests."""
+
+import random
+
+import pytest
+
+from tigas.input_control.protocol import DatagramDecodeError
+from tigas.input_control.state_sync import (
+    STATE_DELTA,
+    STATE_SNAPSHOT,
+    StateSyncAck,
+    StateSyncClient,
+    StateSyncMessage,
+    StateSyncServer,
+)
+
+
+def test_replica_converges_over_lossy_channel() -> None:
+    server = StateSyncServer({"scene": "garden", "exposure": 1.0})
+    client = StateSyncClient()
+    rng = random.Random(469)
+
+    for step in range(200):
+        if step % 7 == 0:
+            server.update({"exposure": round(rng.uniform(0.5, 2.0), 3), f"marker_{step % 5}": step})
+        if step % 31 == 0:
+            server.update({"marker_0": None})
+        server.observe_pose(step)
+        if rng.random() < 0.6:
+            client.receive(StateSyncMessage.decode(server.next_message().encode()))
+        if rng.random() < 0.6:
+            server.acknowledge(StateSyncAck.decode(client.ack().encode()))
+
+    client.receive(server.next_message())
+    assert client.state == server.state
+    assert client.version == server.version
+    assert client.pose_seq == 199
+
+
+def test_deltas_carry_only_changes_since_last_ack() -> None:
+    server = StateSyncServer({"scene": "garden", "exposure": 1.0})
+    client = StateSyncClient()
+    assert server.next_message().kind == STATE_SNAPSHOT
+    client.receive(server.next_message())
+    server.acknowledge(client.ack())
+
+    server.update({"exposure": 1.5})
+    server.update({"scene": None})
+    message = server.next_message()
+
+    assert message.kind == STATE_DELTA
+    assert (message.base_version, message.version) == (1, 3)
+    assert message.changes == {"exposure": 1.5, "scene": None}
+    assert client.receive(message)
+    assert client.state == {"exposure": 1.5}
+
+
+def test_version_gap_triggers_resync_snapshot() -> None:
+    server = StateSyncServer({"scene": "garden"})
+    client = StateSyncClient()
+    client.receive(server.next_message())
+    server.acknowledge(client.ack())
+    server.update({"exposure": 2.0})
+
+    restarted = StateSyncClient()
+    assert not restarted.receive(server.next_message())
+    assert restarted.needs_resync and restarted.gaps_detected == 1
+
+    server.acknowledge(restarted.ack())
+    snapshot = server.next_message()
+    assert snapshot.kind == STATE_SNAPSHOT and server.resyncs == 1
+    assert restarted.receive(snapshot)
+    assert restarted.state == {"scene": "garden", "exposure": 2.0}
+    assert not restarted.needs_resync
+
+
+def test_acks_from_before_a_resync_are_ignored() -> None:
+    server = StateSyncServer({"scene": "garden"})
+    client = StateSyncClient()
+    client.receive(server.next_message())
+    server.acknowledge(client.ack())
+    delayed_ack = client.ack()
+    server.update({"exposure": 2.0})
+
+    restarted = StateSyncClient()
+    assert not restarted.receive(server.next_message())
+    server.acknowledge(restarted.ack())
+    server.acknowledge(delayed_ack)
+
+    snapshot = server.next_message()
+    assert snapshot.kind == STATE_SNAPSHOT and server.stale_acks == 1
+    assert restarted.receive(snapshot)
+    server.acknowledge(restarted.ack())
+    server.update({"exposure": 2.5})
+    delta = server.next_message()
+    assert delta.kind == STATE_DELTA and delta.base_version == snapshot.version
+    assert restarted.receive(delta) and restarted.state == {"scene": "garden", "exposure": 2.5}
+
+
+def test_state_sync_decoding_is_strict() -> None:
+    valid = StateSyncMessage(kind=STATE_DELTA, version=3, base_version=1, changes={"a": 1}).encode()
+    malformed = [
+        b"",
+        b"[]",
+        StateSyncAck(version=1).encode(),
+        valid.replace(b'"delta"', b'"patch"'),
+        valid.replace(b'"base_version":1', b'"base_version":4'),
+        valid.replace(b'"version":3', b'"version":-3'),
+        valid[:-1] + b',"extra":0}',
+    ]
+    for payload in malformed:
+        with pytest.raises(DatagramDecodeError):
+            StateSyncMessage.decode(payload)
+    with pytest.raises(ValueError):
+        StateSyncMessage(kind=STATE_SNAPSHOT, version=1, base_version=0, changes={"blob": "x" * 2000}).encode()
@@ -0,0 +1,72 @@
+"""UDP path probe tests."""
+
+import socket
+
+import pytest
+
+from tigas.instrumentation.udp_probe import UdpProbeResponder, parse_probe_target, probe_path
+
+
+def test_probe_measures_loopback_rtt_and_ignores_malformed_packets() -> None:
+    responder = UdpProbeResponder(host="127.0.0.1", port=0).start()
+    host, port = responder.address
+    try:
+        with socket.socket(socket.AF_INET, socket.SOCK_DGRAM) as stray:
+            stray.sendto(b"x" * 512, (host, port))
+        result = probe_path(host, port, count=5, interval_s=0.005, timeout_s=0.5)
+    finally:
+        responder.stop()
+
+    assert result.sent == 5
+    assert result.received == 5
+    assert result.loss_rate == 0.0
+    assert 0.0 <= result.rtt_ms_min <= result.rtt_ms_mean <= result.rtt_ms_p95
+    assert responder.ignored_count == 1
+
+
+class _RefusingFirstSendSocket:
+    def __init__(self, wrapped: socket.socket) -> None:
+        self._wrapped = wrapped
+        self._refused = False
+
+    def sendto(self, payload: bytes, address) -> int:
+        if not self._refused:
+            self._refused = True
+            raise ConnectionRefusedError("ICMP port unreachable")
+        return self._wrapped.sendto(payload, address)
+
+    def __getattr__(self, name: str):
+        return getattr(self._wrapped, name)
+
+
+def test_responder_keeps_serving_after_send_error() -> None:
+    responder = UdpProbeResponder(host="127.0.0.1", port=0)
+    responder._socket = _RefusingFirstSendSocket(responder._socket)
+    responder.start()
+    host, port = responder.address
+    try:
+        result = probe_path(host, port, count=5, interval_s=0.005, timeout_s=0.5)
+    finally:
+        responder.stop()
+
+    assert responder.send_error_count == 1
+    assert result.received == 4
+    assert responder.echoed_count == 4
+
+
+def test_probe_reports_full_loss_without_responder() -> None:
+    with socket.socket(socket.AF_INET, socket.SOCK_DGRAM) as placeholder:
+        placeholder.bind(("127.0.0.1", 0))
+        host, port = placeholder.getsockname()
+
+        result = probe_path(host, port, count=3, interval_s=0.005, timeout_s=0.05)
+
+    assert result.received == 0
+    assert result.loss_rate == 1.0
+    assert result.rtt_ms_mean is None
+
+
+def test_parse_probe_target() -> None:
+    assert parse_probe_target("10.0.0.2:4434") == ("10.0.0.2", 4434)
+    with pytest.raises(ValueError):
+        parse_probe_target("10.0.0.2")
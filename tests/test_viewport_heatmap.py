@@ -0,0 +1,37 @@
+"""Viewport heatmap aggregation tests."""
+
+from tigas.evaluation.heatmap import ViewportHeatmap, aggregate_heatmaps, save_heatmap
+from tigas.input_control.headless_replayer import HeadlessTraceReplayer
+
+
+def _pose_looking_at(target: tuple[float, float, float]) -> list[float]:
+    return HeadlessTraceReplayer()._look_at_camera_to_world(eye=(0.0, 0.0, 0.0), target=target)
+
+
+def test_heatmap_bins_view_directions_per_segment() -> None:
+    heatmap = ViewportHeatmap(tile_cols=4, tile_rows=2, segment_duration_ms=1000.0)
+    forward = _pose_looking_at((0.0, 0.0, -1.0))
+    backward = _pose_looking_at((0.0, 0.0, 1.0))
+
+    heatmap.add_pose(0.0, forward)
+    heatmap.add_pose(500.0, forward)
+    heatmap.add_pose(1200.0, backward)
+
+    assert heatmap.tile_for(forward) != heatmap.tile_for(backward)
+    assert sum(heatmap.segments[0]) == 2
+    assert heatmap.frequencies(0)[heatmap.tile_for(forward)] == 1.0
+    assert heatmap.frequencies(1)[heatmap.tile_for(backward)] == 1.0
+    assert heatmap.frequencies(7) == [0.0] * 8
+
+
+def test_aggregate_heatmaps_merges_runs(tmp_path) -> None:
+    pose = _pose_looking_at((1.0, 0.0, 0.0))
+    paths = []
+    for run in range(2):
+        heatmap = ViewportHeatmap()
+        heatmap.add_pose(0.0, pose)
+        paths.append(save_heatmap(heatmap, tmp_path / f"heatmap_{run}.json"))
+
+    aggregate = aggregate_heatmaps(paths)
+    assert aggregate.sample_count == 2
+    assert aggregate.segments[0][aggregate.tile_for(pose)] == 2
@@ -13,6 +13,10 @@ export interface UplinkDatagram {
   camera_matrix_4x4: number[];
   requested_lod: LodId;
   target_bitrate_kbps: number;
+  /** Optional deadline for the response frame, in milliseconds. */
+  latency_budget_ms?: number;
+  /** Optional playback position of the displayed frame, in milliseconds. */
+  media_time_ms?: number;
 }
 
 export function serializeDatagram(datagram: UplinkDatagram): Uint8Array {